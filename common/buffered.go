@@ -0,0 +1,55 @@
+package common
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// IssueBuffer holds the issues emitted by a rule checked with CheckBuffered.
+type IssueBuffer struct {
+	sr *subRunner
+}
+
+// CheckBuffered runs the rule against a runner that buffers its issues instead of emitting them,
+// so that the caller can decide whether to report them with Replay.
+func CheckBuffered(runner tflint.Runner, rule tflint.Rule) (*IssueBuffer, error) {
+	sr, err := checkSubRule(runner, rule)
+	if err != nil {
+		return nil, err
+	}
+	return &IssueBuffer{sr: sr}, nil
+}
+
+// Empty returns whether the rule emitted no issues.
+func (b *IssueBuffer) Empty() bool {
+	return len(b.sr.issues) == 0
+}
+
+// Range returns the range of the first issue, or an empty range if there is none.
+func (b *IssueBuffer) Range() hcl.Range {
+	if b.Empty() {
+		return hcl.Range{}
+	}
+	return b.sr.issues[0].issueRange
+}
+
+// Replay emits the buffered issues, with their fixes, as issues of the given rule.
+// Issues emitted with another severity than the severity of the checked rule keep their severity.
+func (b *IssueBuffer) Replay(runner tflint.Runner, rule tflint.Rule) error {
+	for _, i := range b.sr.issues {
+		target := rule
+		if i.severity != b.sr.rule.Severity() {
+			target = WithSeverity(rule, i.severity)
+		}
+		var err error
+		if i.fix != nil {
+			err = runner.EmitIssueWithFix(target, i.message, i.issueRange, i.fix)
+		} else {
+			err = runner.EmitIssue(target, i.message, i.issueRange)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(AnyOfRule)
+var _ tflint.Rule = new(AllOfRule)
+var _ tflint.Rule = new(NotRule)
+var _ config.Configurable = new(AnyOfRule)
+
+// combinator holds the rule metadata shared by the combinator rules.
+// The issues of the combined rules are reported under the name of the combinator.
+type combinator struct {
+	tflint.DefaultRule
+	name     string
+	enabled  bool
+	severity tflint.Severity
+	rules    []tflint.Rule
+}
+
+func (c *combinator) Name() string {
+	return c.name
+}
+
+func (c *combinator) Enabled() bool {
+	return c.enabled
+}
+
+func (c *combinator) Severity() tflint.Severity {
+	return c.severity
+}
+
+// Link returns the link of the first combined rule.
+func (c *combinator) Link() string {
+	if len(c.rules) == 0 {
+		return ""
+	}
+	return c.rules[0].Link()
+}
+
+// ApplyConfig passes the plugin configuration to the combined rules that accept it.
+func (c *combinator) ApplyConfig(cfg *config.Config) error {
+	return applyConfig(c.rules, cfg)
+}
+
+// AnyOfRule passes when at least one of its rules passes, i.e. emits no issue at its own severity,
+// and then reports the downgraded issues of that rule, e.g. warnings, with their own severity.
+// Otherwise it reports the issues of every rule.
+type AnyOfRule struct {
+	combinator
+}
+
+// NewAnyOfRule returns a rule that passes when at least one of the rules passes.
+func NewAnyOfRule(name string, enabled bool, severity tflint.Severity, rules ...tflint.Rule) *AnyOfRule {
+	return &AnyOfRule{combinator{name: name, enabled: enabled, severity: severity, rules: rules}}
+}
+
+func (a *AnyOfRule) Check(runner tflint.Runner) error {
+	var failures []*subRunner
+	for _, r := range a.rules {
+		sr, err := checkSubRule(runner, r)
+		if err != nil {
+			return err
+		}
+		if !sr.failed() {
+			return replay(runner, a, sr)
+		}
+		failures = append(failures, sr)
+	}
+	// Only the fixes of the first rule are offered, the fixes of the alternatives would conflict.
+	return emitFailures(runner, a, failures, failures[:min(1, len(failures))])
+}
+
+// AllOfRule passes when all of its rules pass, i.e. emit no issue at their own severity.
+// It reports the issues of every failing rule, and the downgraded issues of the passing rules with their own severity.
+type AllOfRule struct {
+	combinator
+}
+
+// NewAllOfRule returns a rule that passes when all the rules pass.
+func NewAllOfRule(name string, enabled bool, severity tflint.Severity, rules ...tflint.Rule) *AllOfRule {
+	return &AllOfRule{combinator{name: name, enabled: enabled, severity: severity, rules: rules}}
+}
+
+func (a *AllOfRule) Check(runner tflint.Runner) error {
+	var failures []*subRunner
+	for _, r := range a.rules {
+		sr, err := checkSubRule(runner, r)
+		if err != nil {
+			return err
+		}
+		if sr.failed() {
+			failures = append(failures, sr)
+			continue
+		}
+		if err := replay(runner, a, sr); err != nil {
+			return err
+		}
+	}
+	return emitFailures(runner, a, failures, failures)
+}
+
+// NotRule passes when its rule fails, i.e. emits an issue at its own severity.
+type NotRule struct {
+	combinator
+}
+
+// NewNotRule returns a rule that passes when the rule fails.
+func NewNotRule(name string, enabled bool, severity tflint.Severity, rule tflint.Rule) *NotRule {
+	return &NotRule{combinator{name: name, enabled: enabled, severity: severity, rules: []tflint.Rule{rule}}}
+}
+
+func (n *NotRule) Check(runner tflint.Runner) error {
+	sr, err := checkSubRule(runner, n.rules[0])
+	if err != nil {
+		return err
+	}
+	if sr.failed() {
+		return nil
+	}
+	issueRange, err := moduleRange(runner)
+	if err != nil {
+		return err
+	}
+	return runner.EmitIssue(n, fmt.Sprintf("must not match %s", ruleLabel(sr.rule)), issueRange)
+}
+
+// WithLabel returns the rule with a label that is used instead of its name in the failure summaries
+// of the combinator rules, e.g. to tell apart two variants of the same interface.
+func WithLabel(rule tflint.Rule, label string) tflint.Rule {
+	return &labeledRule{Rule: rule, label: label}
+}
+
+type labeledRule struct {
+	tflint.Rule
+	label string
+}
+
+// ApplyConfig passes the plugin configuration to the labeled rule if it accepts it.
+func (l *labeledRule) ApplyConfig(cfg *config.Config) error {
+	return applyConfig([]tflint.Rule{l.Rule}, cfg)
+}
+
+func applyConfig(rules []tflint.Rule, cfg *config.Config) error {
+	for _, r := range rules {
+		if c, ok := r.(config.Configurable); ok {
+			if err := c.ApplyConfig(cfg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func ruleLabel(rule tflint.Rule) string {
+	if l, ok := rule.(*labeledRule); ok {
+		return l.label
+	}
+	return rule.Name()
+}
+
+// checkSubRule runs the rule against a subRunner that buffers its issues.
+func checkSubRule(runner tflint.Runner, rule tflint.Rule) (*subRunner, error) {
+	sr := &subRunner{
+		Runner: runner,
+		rule:   rule,
+	}
+	if err := rule.Check(sr); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+// emitFailures replays the issues of each failed rule at their own ranges, prefixed with the label of the rule,
+// e.g. "did not match a: issue 1". Downgraded issues keep their severity.
+// Only the issues of the rules in fixFrom are emitted with their fixes.
+func emitFailures(runner tflint.Runner, rule tflint.Rule, failures []*subRunner, fixFrom []*subRunner) error {
+	for _, sr := range failures {
+		withFixes := slices.Contains(fixFrom, sr)
+		for _, i := range sr.issues {
+			if !withFixes {
+				i.fix = nil
+			}
+			i.message = fmt.Sprintf("did not match %s: %s", ruleLabel(sr.rule), i.message)
+			if err := emit(runner, rule, sr, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replay emits the downgraded issues of a rule that passed, e.g. warnings, with their own severity and fixes.
+func replay(runner tflint.Runner, rule tflint.Rule, sr *subRunner) error {
+	for _, i := range sr.issues {
+		if err := emit(runner, rule, sr, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emit emits the issue of the sub-rule as an issue of the rule. Issues at the severity of the sub-rule
+// are reported with the severity of the rule, downgraded issues with their own severity.
+func emit(runner tflint.Runner, rule tflint.Rule, sr *subRunner, i issue) error {
+	if sr.downgraded(i) && i.severity != rule.Severity() {
+		rule = WithSeverity(rule, i.severity)
+	}
+	if i.fix != nil {
+		return runner.EmitIssueWithFix(rule, i.message, i.issueRange, i.fix)
+	}
+	return runner.EmitIssue(rule, i.message, i.issueRange)
+}
+
+// moduleRange returns the start of the first file of the module, in name order,
+// for the issues that are about the module as a whole.
+func moduleRange(runner tflint.Runner) (hcl.Range, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	names := slices.Sorted(maps.Keys(files))
+	if len(names) == 0 {
+		return hcl.Range{}, nil
+	}
+	start := hcl.Pos{Line: 1, Column: 1}
+	return hcl.Range{Filename: names[0], Start: start, End: start}, nil
+}
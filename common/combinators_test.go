@@ -0,0 +1,304 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestCombinators(t *testing.T) {
+	pass := &mockRule{success: true}
+	fail := &mockRule{success: false}
+
+	cases := []struct {
+		name             string
+		rule             tflint.Rule
+		expectedMessages []string
+	}{
+		{
+			name: "any of passes when one rule passes",
+			rule: common.NewAnyOfRule("any", true, tflint.ERROR, fail, fail, pass),
+		},
+		{
+			name: "any of reports every failure",
+			rule: common.NewAnyOfRule("any", true, tflint.ERROR, fail, common.WithLabel(fail, "mock (v2)"), common.WithLabel(fail, "mock (v3)")),
+			expectedMessages: []string{
+				"did not match mock: mock issue",
+				"did not match mock (v2): mock issue",
+				"did not match mock (v3): mock issue",
+			},
+		},
+		{
+			name: "all of passes when every rule passes",
+			rule: common.NewAllOfRule("all", true, tflint.ERROR, pass, pass, pass),
+		},
+		{
+			name:             "all of reports the failures",
+			rule:             common.NewAllOfRule("all", true, tflint.ERROR, pass, common.WithLabel(fail, "mock (v2)")),
+			expectedMessages: []string{"did not match mock (v2): mock issue"},
+		},
+		{
+			name: "not passes when the rule fails",
+			rule: common.NewNotRule("not", true, tflint.ERROR, fail),
+		},
+		{
+			name:             "not fails when the rule passes",
+			rule:             common.NewNotRule("not", true, tflint.ERROR, pass),
+			expectedMessages: []string{"must not match mock"},
+		},
+		{
+			name: "nested combinators",
+			rule: common.NewAllOfRule("all", true, tflint.ERROR,
+				common.NewAnyOfRule("any", true, tflint.ERROR, fail, pass),
+				common.NewNotRule("not", true, tflint.ERROR, fail)),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": ""})
+
+			require.NoError(t, tc.rule.Check(runner))
+
+			messages := make([]string, 0, len(runner.Issues))
+			for _, issue := range runner.Issues {
+				assert.Equal(t, tc.rule, issue.Rule)
+				messages = append(messages, issue.Message)
+			}
+			assert.ElementsMatch(t, tc.expectedMessages, messages)
+		})
+	}
+}
+
+var _ tflint.Rule = &mockAttrRule{}
+
+// mockAttrRule emits an issue on the named attribute of main.tf.
+type mockAttrRule struct {
+	mockRule
+	attr string
+}
+
+func (m *mockAttrRule) Check(r tflint.Runner) error {
+	file, err := r.GetFile("main.tf")
+	if err != nil {
+		return err
+	}
+	attrs, _ := file.Body.JustAttributes()
+	return r.EmitIssue(m, "mock issue", attrs[m.attr].Range)
+}
+
+func TestCombinatorRanges(t *testing.T) {
+	foo := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 12}}
+	bar := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 2, Column: 1}, End: hcl.Pos{Line: 2, Column: 12}}
+	start := hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 1, Column: 1}}
+
+	cases := []struct {
+		name     string
+		rule     tflint.Rule
+		expected helper.Issues
+	}{
+		{
+			name: "any of reports the issues at their own ranges",
+			rule: common.NewAnyOfRule("any", true, tflint.ERROR, &mockAttrRule{attr: "foo"}, common.WithLabel(&mockAttrRule{attr: "bar"}, "mock (v2)")),
+			expected: helper.Issues{
+				{Message: "did not match mock: mock issue", Range: foo},
+				{Message: "did not match mock (v2): mock issue", Range: bar},
+			},
+		},
+		{
+			name: "not reports the issue at the start of the module",
+			rule: common.NewNotRule("not", true, tflint.ERROR, &mockRule{success: true}),
+			expected: helper.Issues{
+				{Message: "must not match mock", Range: start},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      "foo = \"foo\"\nbar = \"bar\"\n",
+				"variables.tf": "",
+			})
+
+			require.NoError(t, tc.rule.Check(runner))
+
+			for _, issue := range tc.expected {
+				issue.Rule = tc.rule
+			}
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+var _ tflint.Rule = &mockFixRule{}
+
+// mockFixRule emits an issue on the `foo` attribute with a fix that replaces its value.
+type mockFixRule struct {
+	mockRule
+	value string
+}
+
+func (m *mockFixRule) Check(r tflint.Runner) error {
+	file, err := r.GetFile("main.tf")
+	if err != nil {
+		return err
+	}
+	attrs, _ := file.Body.JustAttributes()
+	attr := attrs["foo"]
+	return r.EmitIssueWithFix(m, "mock issue", attr.Range, func(f tflint.Fixer) error {
+		return f.ReplaceText(attr.Expr.Range(), m.value)
+	})
+}
+
+func TestCombinatorFixes(t *testing.T) {
+	cases := []struct {
+		name    string
+		rule    tflint.Rule
+		changes map[string]string
+	}{
+		{
+			name:    "any of passes without applying fixes",
+			rule:    common.NewAnyOfRule("any", true, tflint.ERROR, &mockFixRule{value: `"a"`}, &mockRule{success: true}),
+			changes: map[string]string{},
+		},
+		{
+			name:    "any of applies the fixes of the first rule",
+			rule:    common.NewAnyOfRule("any", true, tflint.ERROR, &mockFixRule{value: `"a"`}, &mockFixRule{value: `"b"`}),
+			changes: map[string]string{"main.tf": "foo = \"a\"\n"},
+		},
+		{
+			name:    "all of applies the fixes of the failed rules",
+			rule:    common.NewAllOfRule("all", true, tflint.ERROR, &mockRule{success: true}, &mockFixRule{value: `"b"`}),
+			changes: map[string]string{"main.tf": "foo = \"b\"\n"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"main.tf": "foo = \"bar\"\n"})
+
+			require.NoError(t, tc.rule.Check(runner))
+
+			helper.AssertChanges(t, tc.changes, runner.Changes())
+		})
+	}
+}
+
+var _ tflint.Rule = &mockWarningRule{}
+
+// mockWarningRule emits a warning, which does not fail the rule.
+type mockWarningRule struct {
+	mockRule
+}
+
+func (m *mockWarningRule) Check(r tflint.Runner) error {
+	return r.EmitIssue(common.WithSeverity(m, tflint.WARNING), "mock warning", hcl.Range{})
+}
+
+var _ tflint.Rule = &mockSeverityRule{}
+
+// mockSeverityRule is a rule of the given severity, which fails with an issue at that severity.
+type mockSeverityRule struct {
+	mockRule
+	severity tflint.Severity
+}
+
+func (m *mockSeverityRule) Severity() tflint.Severity {
+	return m.severity
+}
+
+func (m *mockSeverityRule) Check(r tflint.Runner) error {
+	if !m.success {
+		return r.EmitIssue(m, "mock issue", hcl.Range{})
+	}
+	return nil
+}
+
+func TestCombinatorSeverities(t *testing.T) {
+	warn := &mockWarningRule{}
+	fail := &mockRule{success: false}
+	failingWarning := &mockSeverityRule{severity: tflint.WARNING}
+	passingWarning := &mockSeverityRule{mockRule: mockRule{success: true}, severity: tflint.WARNING}
+	failingNotice := &mockSeverityRule{severity: tflint.NOTICE}
+
+	cases := []struct {
+		name     string
+		rule     tflint.Rule
+		expected []tflint.Severity
+	}{
+		{
+			name:     "any of passes with the warnings of the passing rule",
+			rule:     common.NewAnyOfRule("any", true, tflint.ERROR, fail, warn),
+			expected: []tflint.Severity{tflint.WARNING},
+		},
+		{
+			name:     "any of reports the warnings of the failed rules as warnings",
+			rule:     common.NewAnyOfRule("any", true, tflint.ERROR, fail, common.NewAllOfRule("all", true, tflint.ERROR, warn, fail)),
+			expected: []tflint.Severity{tflint.ERROR, tflint.WARNING, tflint.ERROR},
+		},
+		{
+			name:     "all of passes with warnings",
+			rule:     common.NewAllOfRule("all", true, tflint.ERROR, warn, warn),
+			expected: []tflint.Severity{tflint.WARNING, tflint.WARNING},
+		},
+		{
+			name:     "any of tries the next rule when a warning rule fails",
+			rule:     common.NewAnyOfRule("any", true, tflint.ERROR, failingWarning, &mockRule{success: true}),
+			expected: nil,
+		},
+		{
+			name:     "any of reports the failures of warning rules with its own severity",
+			rule:     common.NewAnyOfRule("any", true, tflint.ERROR, failingWarning, failingNotice),
+			expected: []tflint.Severity{tflint.ERROR, tflint.ERROR},
+		},
+		{
+			name:     "all of fails when a notice rule fails",
+			rule:     common.NewAllOfRule("all", true, tflint.WARNING, passingWarning, failingNotice),
+			expected: []tflint.Severity{tflint.WARNING},
+		},
+		{
+			name:     "not passes when a warning rule fails",
+			rule:     common.NewNotRule("not", true, tflint.ERROR, failingWarning),
+			expected: nil,
+		},
+		{
+			name:     "not fails when a warning rule passes",
+			rule:     common.NewNotRule("not", true, tflint.ERROR, passingWarning),
+			expected: []tflint.Severity{tflint.ERROR},
+		},
+		{
+			name:     "not fails when the rule only warns",
+			rule:     common.NewNotRule("not", true, tflint.ERROR, warn),
+			expected: []tflint.Severity{tflint.ERROR},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": ""})
+
+			require.NoError(t, tc.rule.Check(runner))
+
+			var severities []tflint.Severity
+			for _, issue := range runner.Issues {
+				assert.Equal(t, tc.rule.Name(), issue.Rule.Name())
+				severities = append(severities, issue.Rule.Severity())
+			}
+			assert.Equal(t, tc.expected, severities)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package common
+
+import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+// WithSeverity returns the rule with another severity,
+// e.g. to report some of the issues of a rule as warnings.
+func WithSeverity(rule tflint.Rule, severity tflint.Severity) tflint.Rule {
+	return &severityRule{Rule: rule, severity: severity}
+}
+
+type severityRule struct {
+	tflint.Rule
+	severity tflint.Severity
+}
+
+func (s *severityRule) Severity() tflint.Severity {
+	return s.severity
+}
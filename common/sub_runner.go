@@ -5,20 +5,54 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
+// subRunner buffers the issues emitted by a rule, so that a combinator rule
+// can decide whether to report them.
 type subRunner struct {
 	tflint.Runner
+	rule   tflint.Rule
 	issues []issue
 }
 
 type issue struct {
 	message    string
 	issueRange hcl.Range
+	severity   tflint.Severity          // The severity of the rule the issue was emitted with.
+	fix        func(tflint.Fixer) error // The fix offered with the issue, or nil.
 }
 
 func (e *subRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
 	e.issues = append(e.issues, issue{
 		message:    message,
 		issueRange: issueRange,
+		severity:   rule.Severity(),
 	})
 	return nil
 }
+
+// EmitIssueWithFix buffers the issue with its fix, the fix is only applied if the issue is replayed.
+func (e *subRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fixFunc func(f tflint.Fixer) error) error {
+	e.issues = append(e.issues, issue{
+		message:    message,
+		issueRange: issueRange,
+		severity:   rule.Severity(),
+		fix:        fixFunc,
+	})
+	return nil
+}
+
+// failed returns whether the rule emitted an issue at its own severity.
+// Issues that the rule deliberately downgraded, e.g. warnings of an ERROR rule, do not fail it.
+func (e *subRunner) failed() bool {
+	for _, i := range e.issues {
+		if !e.downgraded(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// downgraded returns whether the issue was emitted with a lower severity than the severity of the rule.
+// The severities are ordered from ERROR to NOTICE.
+func (e *subRunner) downgraded(i issue) bool {
+	return i.severity > e.rule.Severity()
+}
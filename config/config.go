@@ -0,0 +1,161 @@
+// Package config provides the plugin configuration for the avm ruleset,
+// i.e. the body of the `plugin "avm" { ... }` block in `.tflint.hcl`.
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Module types that can be declared with the `module_type` attribute.
+const (
+	ModuleTypeResource = "resource"
+	ModuleTypePattern  = "pattern"
+	ModuleTypeUtility  = "utility"
+)
+
+// Config is the plugin configuration, decoded from the `plugin "avm"` block.
+//
+// Example:
+//
+//	plugin "avm" {
+//	  enabled      = true
+//	  module_type  = "resource"
+//	  spec_version = "2024-05-01"
+//
+//	  allowed_module_sources = ["contoso/*/azurerm", "git::https://dev.azure.com/contoso/*/_git/*"]
+//
+//	  provider "azurerm" {
+//	    version                = "3.999.0"
+//	    recommended_constraint = "~> 3.0"
+//	  }
+//
+//	  category "outputs" {
+//	    enabled = false
+//	  }
+//
+//	  interface "private_endpoints" {
+//	    required = false
+//	  }
+//
+//	  interface "role_assignments" {
+//	    revision = "v2"
+//	  }
+//
+//	  interface "managed_identities" {
+//	    allow_extensions = true
+//	  }
+//
+//	  interface_files = ["./lint/interfaces/*.hcl"]
+//	}
+type Config struct {
+	ModuleType           string            `hclext:"module_type,optional"`            // The type of the module, one of resource, pattern or utility. Inferred from the module name if not set.
+	SpecVersion          string            `hclext:"spec_version,optional"`           // The snapshot of the AVM specification to check against.
+	AllowedModuleSources []string          `hclext:"allowed_module_sources,optional"` // Module source prefixes or glob patterns allowed in `module` blocks.
+	Providers            []ProviderConfig  `hclext:"provider,block"`                  // Provider version targets.
+	Categories           []CategoryConfig  `hclext:"category,block"`                  // Rule category toggles.
+	Interfaces           []InterfaceConfig `hclext:"interface,block"`                 // Per interface settings.
+	InterfaceFiles       []string          `hclext:"interface_files,optional"`        // Glob patterns of custom interface specification files, relative to the directory TFLint is run from.
+}
+
+// ProviderConfig overrides the version target of a provider version rule.
+type ProviderConfig struct {
+	Name                  string `hclext:"name,label"`
+	Version               string `hclext:"version,optional"`                // The version the provider constraint must satisfy.
+	RecommendedConstraint string `hclext:"recommended_constraint,optional"` // The constraint suggested in the issue message.
+	MustExist             *bool  `hclext:"must_exist"`                      // Whether the provider must be declared.
+}
+
+// CategoryConfig enables or disables all rules of a category.
+type CategoryConfig struct {
+	Name    string `hclext:"name,label"`
+	Enabled bool   `hclext:"enabled"`
+}
+
+// InterfaceConfig holds the settings of an interface.
+type InterfaceConfig struct {
+	Name            string `hclext:"name,label"`
+	Required        *bool  `hclext:"required"`          // Whether the interface must be implemented when the primary resource supports it.
+	Revision        string `hclext:"revision,optional"` // The revision of the interface specification to check against.
+	AllowExtensions *bool  `hclext:"allow_extensions"`  // Whether the variable type may add optional attributes to the interface.
+}
+
+// Profiled is implemented by rules that only apply to some module types.
+// The rules that do not implement it apply to all modules.
+type Profiled interface {
+	ModuleTypes() []string
+}
+
+// Configurable is implemented by rules that accept the plugin configuration.
+// ApplyConfig is called once the configuration has been decoded, before any Check.
+type Configurable interface {
+	ApplyConfig(*Config) error
+}
+
+// Validate checks the decoded configuration for values we cannot act on.
+func (c *Config) Validate() error {
+	validModuleTypes := []string{"", ModuleTypeResource, ModuleTypePattern, ModuleTypeUtility}
+	if !slices.Contains(validModuleTypes, c.ModuleType) {
+		return fmt.Errorf("invalid module_type %q, must be one of %q, %q or %q", c.ModuleType, ModuleTypeResource, ModuleTypePattern, ModuleTypeUtility)
+	}
+	// The dates are compared as strings, which only orders them correctly in the YYYY-MM-DD format.
+	if c.SpecVersion != "" {
+		if _, err := time.Parse(time.DateOnly, c.SpecVersion); err != nil {
+			return fmt.Errorf("invalid spec_version %q, must be a date in the YYYY-MM-DD format", c.SpecVersion)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range c.Providers {
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate provider block %q", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	seen = make(map[string]bool)
+	for _, cat := range c.Categories {
+		if seen[cat.Name] {
+			return fmt.Errorf("duplicate category block %q", cat.Name)
+		}
+		seen[cat.Name] = true
+	}
+	seen = make(map[string]bool)
+	for _, i := range c.Interfaces {
+		if seen[i.Name] {
+			return fmt.Errorf("duplicate interface block %q", i.Name)
+		}
+		seen[i.Name] = true
+	}
+	return nil
+}
+
+// Provider returns the configuration for the named provider, or nil if there is none.
+func (c *Config) Provider(name string) *ProviderConfig {
+	for i := range c.Providers {
+		if c.Providers[i].Name == name {
+			return &c.Providers[i]
+		}
+	}
+	return nil
+}
+
+// CategoryEnabled returns whether the named category is enabled.
+// Categories are enabled unless explicitly disabled.
+func (c *Config) CategoryEnabled(name string) bool {
+	for _, cat := range c.Categories {
+		if cat.Name == name {
+			return cat.Enabled
+		}
+	}
+	return true
+}
+
+// Interface returns the configuration for the named interface, or nil if there is none.
+func (c *Config) Interface(name string) *InterfaceConfig {
+	for i := range c.Interfaces {
+		if c.Interfaces[i].Name == name {
+			return &c.Interfaces[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package interfaces
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// CustomerManagedKeyUsage checks that the `customer_managed_key` variable is consumed by the CMK arguments of the module.
+var CustomerManagedKeyUsage = &InterfaceUsageRule{
+	RuleName:        "customer_managed_key_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/shared/interfaces/#customer-managed-keys",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "customer_managed_key",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkCustomerManagedKeyUsage,
+}
+
+// customerManagedKeyFields are the attributes of the interface object that must reach a resource.
+// `key_version` and `user_assigned_identity` are optional for the caller, not for the module:
+// when they are set, they must be passed on.
+var customerManagedKeyFields = [][]string{
+	{"key_vault_resource_id"},
+	{"key_name"},
+	{"key_version"},
+	{"user_assigned_identity", "resource_id"},
+}
+
+// customerManagedKeyArguments are the arguments that configure the customer managed key of a resource,
+// as nested blocks or attributes.
+var customerManagedKeyArguments = []string{"customer_managed_key", "encryption", "key_vault_key_id"}
+
+// checkCustomerManagedKeyUsage checks that every attribute of `var.customer_managed_key` is used by a resource,
+// either directly or through locals and data sources, e.g. a `azurerm_key_vault_key` data source.
+// The CMK arguments of the primary resources, when it sets them itself, must come from the variable too.
+func checkCustomerManagedKeyUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	used := func(path ...string) bool {
+		for _, b := range m.resources {
+			if m.blockRefersTo(b, append([]string{"var", "customer_managed_key"}, path...)...) {
+				return true
+			}
+		}
+		return false
+	}
+	if !used() {
+		return r.EmitIssue(rule, "`customer_managed_key` variable is declared but no resource uses it", variable.DefRange())
+	}
+	for _, field := range customerManagedKeyFields {
+		if used(field...) {
+			continue
+		}
+		msg := fmt.Sprintf("`var.customer_managed_key.%s` is not used by any resource", strings.Join(field, "."))
+		if err := r.EmitIssue(rule, msg, variable.DefRange()); err != nil {
+			return err
+		}
+	}
+	for _, b := range m.primaryResources(nil) {
+		if err := checkPrimaryCustomerManagedKey(rule, r, m, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPrimaryCustomerManagedKey emits an issue for each CMK argument of the primary resource that does not
+// refer to `var.customer_managed_key`. A resource that sets none of them, e.g. because the key is configured
+// by a separate `*_customer_managed_key` resource, is not reported.
+func checkPrimaryCustomerManagedKey(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, b *hclsyntax.Block) error {
+	for _, name := range customerManagedKeyArguments {
+		msg := fmt.Sprintf("`%s.%s` must set `%s` from `var.customer_managed_key`", b.Labels[0], b.Labels[1], name)
+		if attr, ok := b.Body.Attributes[name]; ok && !m.refersTo(attr.Expr, "var", "customer_managed_key") {
+			if err := r.EmitIssue(rule, msg, attr.SrcRange); err != nil {
+				return err
+			}
+		}
+		for _, nested := range append(blocksOfType(b, name), dynamicBlocks(b, name)...) {
+			if m.blockRefersTo(nested, "var", "customer_managed_key") {
+				continue
+			}
+			if err := r.EmitIssue(rule, msg, nested.DefRange()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestCustomerManagedKeyUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.CustomerManagedKey)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "key from a data source",
+			Content: `data "azurerm_key_vault_key" "cmk" {
+  count = var.customer_managed_key != null ? 1 : 0
+
+  key_vault_id = var.customer_managed_key.key_vault_resource_id
+  name         = var.customer_managed_key.key_name
+}
+
+resource "azurerm_storage_account_customer_managed_key" "this" {
+  count = var.customer_managed_key != null ? 1 : 0
+
+  key_vault_id              = data.azurerm_key_vault_key.cmk[0].key_vault_id
+  key_name                  = data.azurerm_key_vault_key.cmk[0].name
+  key_version               = var.customer_managed_key.key_version
+  storage_account_id        = azurerm_storage_account.this.id
+  user_assigned_identity_id = try(var.customer_managed_key.user_assigned_identity.resource_id, null)
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing key version and identity",
+			Content: `resource "azurerm_storage_account" "this" {
+  dynamic "customer_managed_key" {
+    for_each = var.customer_managed_key != null ? [var.customer_managed_key] : []
+
+    content {
+      key_vault_key_id = "${customer_managed_key.value.key_vault_resource_id}/keys/${customer_managed_key.value.key_name}"
+    }
+  }
+}
+
+resource "azurerm_key_vault_key" "cmk" {
+  key_vault_id = var.customer_managed_key.key_vault_resource_id
+  name         = var.customer_managed_key.key_name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`var.customer_managed_key.key_version` is not used by any resource",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 32},
+					},
+				},
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`var.customer_managed_key.user_assigned_identity.resource_id` is not used by any resource",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "missing key name",
+			Content: `resource "azurerm_storage_account" "this" {
+  dynamic "customer_managed_key" {
+    for_each = var.customer_managed_key != null ? [var.customer_managed_key] : []
+
+    content {
+      key_vault_key_id = customer_managed_key.value.key_vault_resource_id
+    }
+  }
+}
+
+resource "azurerm_key_vault_key" "cmk" {
+  key_vault_id = var.customer_managed_key.key_vault_resource_id
+}
+
+resource "azurerm_storage_account_customer_managed_key" "cmk" {
+  key_version               = var.customer_managed_key.key_version
+  user_assigned_identity_id = var.customer_managed_key.user_assigned_identity.resource_id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`var.customer_managed_key.key_name` is not used by any resource",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "primary resource key not from the variable",
+			Content: `resource "azurerm_storage_account" "this" {
+  customer_managed_key {
+    key_vault_key_id = azurerm_key_vault_key.other.id
+  }
+}
+
+resource "azurerm_key_vault_key" "other" {
+  key_vault_id = var.customer_managed_key.key_vault_resource_id
+  name         = var.customer_managed_key.key_name
+}
+
+resource "azurerm_storage_account_customer_managed_key" "cmk" {
+  key_version               = var.customer_managed_key.key_version
+  user_assigned_identity_id = var.customer_managed_key.user_assigned_identity.resource_id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`azurerm_storage_account.this` must set `customer_managed_key` from `var.customer_managed_key`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "primary resource key attribute not from the variable",
+			Content: `resource "azurerm_container_registry" "this" {
+  key_vault_key_id = "fixed"
+}
+
+resource "azurerm_key_vault_key" "other" {
+  key_vault_id = var.customer_managed_key.key_vault_resource_id
+  name         = var.customer_managed_key.key_name
+}
+
+resource "azurerm_storage_account_customer_managed_key" "cmk" {
+  key_version               = var.customer_managed_key.key_version
+  user_assigned_identity_id = var.customer_managed_key.user_assigned_identity.resource_id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`azurerm_container_registry.this` must set `key_vault_key_id` from `var.customer_managed_key`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name:    "not used",
+			Content: `resource "azurerm_storage_account" "this" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.CustomerManagedKeyUsage,
+					Message: "`customer_managed_key` variable is declared but no resource uses it",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 32},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Content,
+				"variables.tf": variable,
+			})
+
+			if err := interfaces.CustomerManagedKeyUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
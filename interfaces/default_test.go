@@ -0,0 +1,78 @@
+package interfaces_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+// TestInterfaceDefaultValue tests the comparison of the default values of interface variables.
+// Terraform itself rejects references and function calls in variable defaults, so the test runner cannot load them.
+func TestInterfaceDefaultValue(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Interface interfaces.AvmInterface
+		Default   string
+		Expected  helper.Issues
+	}{
+		{
+			Name:      "equal once the optional attribute defaults are applied",
+			Interface: interfaces.ManagedIdentities,
+			Default:   `{ system_assigned = false }`,
+			Expected:  helper.Issues{},
+		},
+		{
+			Name:      "null",
+			Interface: interfaces.Tags,
+			Default:   `null`,
+			Expected:  helper.Issues{},
+		},
+		{
+			Name:      "incorrect value",
+			Interface: interfaces.ManagedIdentities,
+			Default:   `{ system_assigned = true, user_assigned_resource_ids = ["/id"] }`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.ManagedIdentities),
+					Message: `default value is not correct, expected {}, got { system_assigned = true, user_assigned_resource_ids = ["/id"] }, see: ` + interfaces.ManagedIdentities.RuleLink,
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 30},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			nullable := ""
+			if !tc.Interface.Nullable {
+				nullable = "\n  nullable = false"
+			}
+			validations := ""
+			for _, v := range tc.Interface.Validations {
+				validations += fmt.Sprintf("\n  validation {\n    condition     = %s\n    error_message = %q\n  }", v.Condition, v.Description)
+			}
+			content := fmt.Sprintf(`
+variable "%s" {
+  default = %s
+  type    = %s%s%s
+}`, tc.Interface.RuleName, tc.Default, tc.Interface.VarTypeString, nullable, validations)
+			rule := interfaces.NewVarCheckRuleFromAvmInterface(tc.Interface)
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
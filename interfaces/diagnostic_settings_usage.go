@@ -0,0 +1,102 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// DiagnosticSettingsUsage checks that the `diagnostic_settings` variable is consumed by diagnostic setting resources.
+var DiagnosticSettingsUsage = &InterfaceUsageRule{
+	RuleName:        "diagnostic_settings_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#diagnostic-settings",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "diagnostic_settings",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkDiagnosticSettingsUsage,
+}
+
+// diagnosticSettingsAttributes maps the attributes of the interface object to the
+// attributes of the `azurerm_monitor_diagnostic_setting` resource.
+var diagnosticSettingsAttributes = []struct{ attr, field string }{
+	{"log_analytics_workspace_id", "workspace_resource_id"},
+	{"storage_account_id", "storage_account_resource_id"},
+	{"eventhub_authorization_rule_id", "event_hub_authorization_rule_resource_id"},
+	{"eventhub_name", "event_hub_name"},
+	{"partner_solution_id", "marketplace_partner_resource_id"},
+	{"log_analytics_destination_type", "log_analytics_destination_type"},
+}
+
+// diagnosticSettingsAzapiProperties maps the attributes of the interface object to the
+// properties of the `Microsoft.Insights/diagnosticSettings` resource.
+var diagnosticSettingsAzapiProperties = []struct{ property, field string }{
+	{"properties.workspaceId", "workspace_resource_id"},
+	{"properties.storageAccountId", "storage_account_resource_id"},
+	{"properties.eventHubAuthorizationRuleId", "event_hub_authorization_rule_resource_id"},
+	{"properties.eventHubName", "event_hub_name"},
+	{"properties.marketplacePartnerId", "marketplace_partner_resource_id"},
+	{"properties.logAnalyticsDestinationType", "log_analytics_destination_type"},
+	{"properties.logs[*].category", "log_categories"},
+	{"properties.logs[*].categoryGroup", "log_groups"},
+	{"properties.metrics[*].category", "metric_categories"},
+}
+
+// diagnosticSettingsDynamicBlocks are the dynamic blocks of the `azurerm_monitor_diagnostic_setting` resource
+// that must iterate over the category sets of the interface object.
+// The `metric` block was renamed to `enabled_metric` in azurerm v4, both are accepted.
+var diagnosticSettingsDynamicBlocks = []struct {
+	blockTypes []string
+	attr       string
+	field      string
+}{
+	{[]string{"enabled_log"}, "category", "log_categories"},
+	{[]string{"enabled_log"}, "category_group", "log_groups"},
+	{[]string{"metric", "enabled_metric"}, "category", "metric_categories"},
+}
+
+// checkDiagnosticSettingsUsage checks the diagnostic setting resources that iterate over `var.diagnostic_settings`.
+func checkDiagnosticSettingsUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	found := false
+	for _, b := range append(m.resourcesOfType("azurerm_monitor_diagnostic_setting"), m.azapiResourcesOfType("Microsoft.Insights/diagnosticSettings")...) {
+		if !m.attrRefersTo(b, "for_each", "var", "diagnostic_settings") {
+			continue
+		}
+		found = true
+		if err := checkDiagnosticSettingWiring(rule, r, m, b); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return r.EmitIssue(rule, "`diagnostic_settings` variable is declared but no `azurerm_monitor_diagnostic_setting` or `Microsoft.Insights/diagnosticSettings` resource iterates over it with `for_each`", variable.DefRange())
+	}
+	return nil
+}
+
+// checkDiagnosticSettingWiring checks that the destinations and categories of the interface object are wired to the resource.
+func checkDiagnosticSettingWiring(rule tflint.Rule, r tflint.Runner, m *moduleUsage, b *hclsyntax.Block) error {
+	if b.Labels[0] == "azapi_resource" {
+		for _, p := range diagnosticSettingsAzapiProperties {
+			if err := m.checkEachValueProperty(rule, r, b, p.property, p.field); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, a := range diagnosticSettingsAttributes {
+		if err := m.checkEachValueAttr(rule, r, b, a.attr, a.field); err != nil {
+			return err
+		}
+	}
+	for _, d := range diagnosticSettingsDynamicBlocks {
+		if m.hasDynamicBlockFrom(b, d.blockTypes, d.attr, d.field) {
+			continue
+		}
+		msg := fmt.Sprintf("`%s.%s` must set `dynamic \"%s\"` `content.%s` for each element of `each.value.%s`", b.Labels[0], b.Labels[1], d.blockTypes[0], d.attr, d.field)
+		if err := r.EmitIssue(rule, msg, b.DefRange()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestDiagnosticSettingsUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.DiagnosticSettings)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "azurerm diagnostic setting",
+			Content: `resource "azurerm_monitor_diagnostic_setting" "this" {
+  for_each = var.diagnostic_settings
+
+  name                           = each.value.name != null ? each.value.name : "diag-${var.name}"
+  target_resource_id             = azurerm_key_vault.this.id
+  eventhub_authorization_rule_id = each.value.event_hub_authorization_rule_resource_id
+  eventhub_name                  = each.value.event_hub_name
+  log_analytics_destination_type = each.value.log_analytics_destination_type
+  log_analytics_workspace_id     = each.value.workspace_resource_id
+  partner_solution_id            = each.value.marketplace_partner_resource_id
+  storage_account_id             = each.value.storage_account_resource_id
+
+  dynamic "enabled_log" {
+    for_each = each.value.log_categories
+
+    content {
+      category = enabled_log.value
+    }
+  }
+  dynamic "enabled_log" {
+    for_each = each.value.log_groups
+    iterator = group
+
+    content {
+      category_group = group.value
+    }
+  }
+  dynamic "metric" {
+    for_each = each.value.metric_categories
+
+    content {
+      category = metric.value
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing wiring",
+			Content: `resource "azurerm_monitor_diagnostic_setting" "this" {
+  for_each = var.diagnostic_settings
+
+  name                           = each.value.name
+  target_resource_id             = azurerm_key_vault.this.id
+  eventhub_authorization_rule_id = each.value.event_hub_authorization_rule_resource_id
+  eventhub_name                  = each.value.event_hub_name
+  log_analytics_destination_type = "Dedicated"
+  log_analytics_workspace_id     = each.value.workspace_resource_id
+  storage_account_id             = each.value.storage_account_resource_id
+
+  dynamic "enabled_log" {
+    for_each = each.value.log_categories
+
+    content {
+      category = enabled_log.value
+    }
+  }
+  dynamic "enabled_metric" {
+    for_each = each.value.metric_categories
+
+    content {
+      category = enabled_metric.value
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.DiagnosticSettingsUsage,
+					Message: "`azurerm_monitor_diagnostic_setting.this` must set `partner_solution_id` from `each.value.marketplace_partner_resource_id`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 53},
+					},
+				},
+				{
+					Rule:    interfaces.DiagnosticSettingsUsage,
+					Message: "`azurerm_monitor_diagnostic_setting.this` must set `log_analytics_destination_type` from `each.value.log_analytics_destination_type`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 47},
+					},
+				},
+				{
+					Rule:    interfaces.DiagnosticSettingsUsage,
+					Message: "`azurerm_monitor_diagnostic_setting.this` must set `dynamic \"enabled_log\"` `content.category_group` for each element of `each.value.log_groups`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name:    "not iterated",
+			Content: `resource "azurerm_monitor_diagnostic_setting" "this" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.DiagnosticSettingsUsage,
+					Message: "`diagnostic_settings` variable is declared but no `azurerm_monitor_diagnostic_setting` or `Microsoft.Insights/diagnosticSettings` resource iterates over it with `for_each`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 31},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Content,
+				"variables.tf": variable,
+			})
+
+			if err := interfaces.DiagnosticSettingsUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
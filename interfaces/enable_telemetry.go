@@ -0,0 +1,7 @@
+package interfaces
+
+// EnableTelemetryTypeString is the type constraint string for the telemetry interface.
+var EnableTelemetryTypeString = EnableTelemetry.VarTypeString
+
+// EnableTelemetry is the telemetry interface, see spec/enable_telemetry.hcl.
+var EnableTelemetry = specInterface("enable_telemetry")
@@ -0,0 +1,53 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+// TestTerraformEnableTelemetryInterface tests the telemetry interface.
+func TestTerraformEnableTelemetryInterface(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "correct",
+			Content:  toTerraformVarType(interfaces.EnableTelemetry),
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "incorrect default",
+			Content: `variable "enable_telemetry" {
+  type     = bool
+  default  = false
+  nullable = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.EnableTelemetry),
+					Message: "default value is not correct, expected true, got false, see: " + interfaces.EnableTelemetry.RuleLink,
+				},
+			},
+		},
+	}
+
+	rule := interfaces.NewVarCheckRuleFromAvmInterface(interfaces.EnableTelemetry)
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssuesWithoutRange(t, tc.Expected, runner.Issues)
+		})
+	}
+}
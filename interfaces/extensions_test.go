@@ -0,0 +1,182 @@
+package interfaces_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestInterfaceExtensions(t *testing.T) {
+	allow := func(name string) *config.Config {
+		allowed := true
+		return &config.Config{Interfaces: []config.InterfaceConfig{{Name: name, AllowExtensions: &allowed}}}
+	}
+
+	cases := []struct {
+		Name             string
+		Interface        interfaces.AvmInterface
+		Config           *config.Config
+		Content          string
+		ExpectedSeverity []tflint.Severity
+		ExpectedMessages []string
+	}{
+		{
+			Name:      "extension not allowed",
+			Interface: interfaces.ManagedIdentities,
+			Content: `
+variable "managed_identities" {
+  type = object({
+    system_assigned            = optional(bool, false)
+    user_assigned_resource_ids = optional(set(string), [])
+    federated_credentials      = optional(map(string), {})
+  })
+  default  = {}
+  nullable = false
+}`,
+			ExpectedSeverity: []tflint.Severity{tflint.ERROR},
+			ExpectedMessages: []string{
+				"variable type does not comply with the interface specification: managed_identities.federated_credentials: unexpected attribute optional(map(string), {})",
+			},
+		},
+		{
+			Name:      "optional extension allowed",
+			Interface: interfaces.ManagedIdentities,
+			Config:    allow("managed_identities"),
+			Content: `
+variable "managed_identities" {
+  type = object({
+    system_assigned            = optional(bool, false)
+    user_assigned_resource_ids = optional(set(string), [])
+    federated_credentials      = optional(map(string), {})
+  })
+  default  = {}
+  nullable = false
+}`,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessages: []string{
+				"variable type extends the interface specification: managed_identities.federated_credentials is declared as optional(map(string), {}), which is not part of the interface. The extension is compatible as the attribute is optional",
+			},
+		},
+		{
+			Name:      "required extension and missing attribute remain errors",
+			Interface: interfaces.ManagedIdentities,
+			Config:    allow("managed_identities"),
+			Content: `
+variable "managed_identities" {
+  type = object({
+    system_assigned = optional(bool, false)
+    client_id       = string
+  })
+  default  = {}
+  nullable = false
+}`,
+			ExpectedSeverity: []tflint.Severity{tflint.ERROR, tflint.ERROR},
+			ExpectedMessages: []string{
+				"variable type does not comply with the interface specification: managed_identities.client_id: unexpected attribute string",
+				"variable type does not comply with the interface specification: managed_identities.user_assigned_resource_ids: missing attribute, expected optional(set(string), [])",
+			},
+		},
+		{
+			Name:      "retyped attribute remains an error",
+			Interface: interfaces.ManagedIdentities,
+			Config:    allow("managed_identities"),
+			Content: `
+variable "managed_identities" {
+  type = object({
+    system_assigned            = optional(string, "false")
+    user_assigned_resource_ids = optional(set(string), [])
+    federated_credentials      = optional(map(string), {})
+  })
+  default  = {}
+  nullable = false
+}`,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING, tflint.ERROR},
+			ExpectedMessages: []string{
+				"variable type extends the interface specification: managed_identities.federated_credentials is declared as optional(map(string), {}), which is not part of the interface. The extension is compatible as the attribute is optional",
+				"variable type does not comply with the interface specification: managed_identities.system_assigned: expected optional(bool, false), got optional(string, \"false\")",
+			},
+		},
+		{
+			Name:      "extension of an interface with revisions",
+			Interface: interfaces.RoleAssignments,
+			Config:    allow("role_assignments"),
+			Content: fmt.Sprintf(`
+variable "role_assignments" {
+  type = map(object({
+    role_definition_id_or_name             = string
+    principal_id                           = string
+    description                            = optional(string, null)
+    skip_service_principal_aad_check       = optional(bool, false)
+    condition                              = optional(string, null)
+    condition_version                      = optional(string, null)
+    delegated_managed_identity_resource_id = optional(string, null)
+    principal_type                         = optional(string, null)
+    scope                                  = optional(string, null)
+  }))
+  default  = {}
+  nullable = false
+%s}`, roleAssignmentsValidation),
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessages: []string{
+				"variable type extends the interface specification: role_assignments.*.scope is declared as optional(string, null), which is not part of the interface. The extension is compatible as the attribute is optional",
+			},
+		},
+		{
+			Name:      "extension of a nested interface",
+			Interface: interfaces.Lock,
+			Config:    allow("lock"),
+			Content: `
+variable "containers" {
+  type = map(object({
+    lock = optional(object({
+      kind  = string
+      name  = optional(string, null)
+      notes = optional(string, null)
+    }), null)
+  }))
+}`,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessages: []string{
+				"nested `lock` interface extends the interface specification: containers.*.lock.notes is declared as optional(string, null), which is not part of the interface. The extension is compatible as the attribute is optional",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			rule := interfaces.NewVarCheckRuleFromAvmInterface(tc.Interface)
+			if tc.Config != nil {
+				require.NoError(t, rule.ApplyConfig(tc.Config))
+			}
+			require.NoError(t, rule.Check(runner))
+
+			var severities []tflint.Severity
+			var messages []string
+			for _, issue := range runner.Issues {
+				severities = append(severities, issue.Rule.Severity())
+				messages = append(messages, issue.Message)
+				assert.Equal(t, tc.Interface.RuleName, issue.Rule.Name())
+			}
+			assert.Equal(t, tc.ExpectedSeverity, severities)
+			assert.Equal(t, tc.ExpectedMessages, messages)
+		})
+	}
+}
+
+// roleAssignmentsValidation is a validation block that implements the role assignments interface validation.
+const roleAssignmentsValidation = `
+  validation {
+    condition     = alltrue([for _, v in var.role_assignments : v.principal_type == null ? true : contains(["User", "Group", "ServicePrincipal", "ForeignGroup", "Device"], v.principal_type)])
+    error_message = "Invalid principal_type."
+  }
+`
@@ -0,0 +1,443 @@
+package interfaces
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// terraformFunctions are the pure Terraform built-in functions, i.e. those that can be evaluated
+// without access to the file system, the clock or providers.
+// The network (cidr*), YAML, template and UUID functions are not available, calls to them
+// and to provider functions fail to evaluate, see conditionResult.
+// It is used to evaluate validation conditions and default values.
+var terraformFunctions = map[string]function.Function{
+	"abs":             stdlib.AbsoluteFunc,
+	"alltrue":         allTrueFunc,
+	"anytrue":         anyTrueFunc,
+	"base64decode":    base64DecodeFunc,
+	"base64encode":    base64EncodeFunc,
+	"base64sha256":    makeHashFunc(sha256.New, base64.StdEncoding.EncodeToString),
+	"base64sha512":    makeHashFunc(sha512.New, base64.StdEncoding.EncodeToString),
+	"basename":        makeStringFunc(filepath.Base),
+	"can":             tryfunc.CanFunc,
+	"ceil":            stdlib.CeilFunc,
+	"chomp":           stdlib.ChompFunc,
+	"chunklist":       stdlib.ChunklistFunc,
+	"coalesce":        stdlib.CoalesceFunc,
+	"coalescelist":    stdlib.CoalesceListFunc,
+	"compact":         stdlib.CompactFunc,
+	"concat":          stdlib.ConcatFunc,
+	"contains":        stdlib.ContainsFunc,
+	"csvdecode":       stdlib.CSVDecodeFunc,
+	"dirname":         makeStringFunc(filepath.Dir),
+	"distinct":        stdlib.DistinctFunc,
+	"element":         stdlib.ElementFunc,
+	"endswith":        makeStringTestFunc(strings.HasSuffix),
+	"flatten":         stdlib.FlattenFunc,
+	"floor":           stdlib.FloorFunc,
+	"format":          stdlib.FormatFunc,
+	"formatdate":      stdlib.FormatDateFunc,
+	"formatlist":      stdlib.FormatListFunc,
+	"indent":          stdlib.IndentFunc,
+	"index":           stdlib.IndexFunc,
+	"issensitive":     isSensitiveFunc,
+	"join":            stdlib.JoinFunc,
+	"jsondecode":      stdlib.JSONDecodeFunc,
+	"jsonencode":      stdlib.JSONEncodeFunc,
+	"keys":            stdlib.KeysFunc,
+	"length":          stdlib.LengthFunc,
+	"log":             stdlib.LogFunc,
+	"lookup":          stdlib.LookupFunc,
+	"lower":           stdlib.LowerFunc,
+	"matchkeys":       matchKeysFunc,
+	"max":             stdlib.MaxFunc,
+	"md5":             makeHashFunc(md5.New, hex.EncodeToString),
+	"merge":           stdlib.MergeFunc,
+	"min":             stdlib.MinFunc,
+	"nonsensitive":    identityFunc,
+	"one":             oneFunc,
+	"parseint":        stdlib.ParseIntFunc,
+	"pow":             stdlib.PowFunc,
+	"range":           stdlib.RangeFunc,
+	"regex":           stdlib.RegexFunc,
+	"regexall":        stdlib.RegexAllFunc,
+	"replace":         replaceFunc,
+	"reverse":         stdlib.ReverseListFunc,
+	"sensitive":       identityFunc,
+	"setintersection": stdlib.SetIntersectionFunc,
+	"setproduct":      stdlib.SetProductFunc,
+	"setsubtract":     stdlib.SetSubtractFunc,
+	"setunion":        stdlib.SetUnionFunc,
+	"sha1":            makeHashFunc(sha1.New, hex.EncodeToString),
+	"sha256":          makeHashFunc(sha256.New, hex.EncodeToString),
+	"sha512":          makeHashFunc(sha512.New, hex.EncodeToString),
+	"signum":          stdlib.SignumFunc,
+	"slice":           stdlib.SliceFunc,
+	"sort":            stdlib.SortFunc,
+	"split":           stdlib.SplitFunc,
+	"startswith":      makeStringTestFunc(strings.HasPrefix),
+	"strcontains":     makeStringTestFunc(strings.Contains),
+	"strrev":          stdlib.ReverseFunc,
+	"substr":          stdlib.SubstrFunc,
+	"sum":             sumFunc,
+	"timeadd":         stdlib.TimeAddFunc,
+	"timecmp":         timeCmpFunc,
+	"title":           stdlib.TitleFunc,
+	"tobool":          stdlib.MakeToFunc(cty.Bool),
+	"tolist":          stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":           stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":        stdlib.MakeToFunc(cty.Number),
+	"toset":           stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":        stdlib.MakeToFunc(cty.String),
+	"transpose":       transposeFunc,
+	"trim":            stdlib.TrimFunc,
+	"trimprefix":      stdlib.TrimPrefixFunc,
+	"trimspace":       stdlib.TrimSpaceFunc,
+	"trimsuffix":      stdlib.TrimSuffixFunc,
+	"try":             tryfunc.TryFunc,
+	"upper":           stdlib.UpperFunc,
+	"urlencode":       makeStringFunc(url.QueryEscape),
+	"values":          stdlib.ValuesFunc,
+	"zipmap":          stdlib.ZipmapFunc,
+}
+
+// newEvalContext returns an evaluation context with the Terraform functions
+// and the given variables available as `var.<name>`.
+func newEvalContext(vars map[string]cty.Value) *hcl.EvalContext {
+	ctx := &hcl.EvalContext{
+		Functions: terraformFunctions,
+	}
+	if len(vars) > 0 {
+		ctx.Variables = map[string]cty.Value{
+			"var": cty.ObjectVal(vars),
+		}
+	}
+	return ctx
+}
+
+// allTrueFunc is the Terraform `alltrue` function.
+var allTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.List(cty.Bool)},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		result := cty.True
+		for it := args[0].ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			if !v.IsKnown() {
+				return cty.UnknownVal(cty.Bool), nil
+			}
+			if v.IsNull() {
+				return cty.False, nil
+			}
+			result = result.And(v)
+			if result.False() {
+				return cty.False, nil
+			}
+		}
+		return result, nil
+	},
+})
+
+// anyTrueFunc is the Terraform `anytrue` function.
+var anyTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.List(cty.Bool)},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		result := cty.False
+		var hasUnknown bool
+		for it := args[0].ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			if !v.IsKnown() {
+				hasUnknown = true
+				continue
+			}
+			if v.IsNull() {
+				continue
+			}
+			result = result.Or(v)
+			if result.True() {
+				return cty.True, nil
+			}
+		}
+		if hasUnknown {
+			return cty.UnknownVal(cty.Bool), nil
+		}
+		return result, nil
+	},
+})
+
+// oneFunc is the Terraform `one` function: the single element of a list, set or tuple,
+// or null if it is empty.
+var oneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		ty := args[0].Type()
+		switch {
+		case ty.IsListType() || ty.IsSetType():
+			return ty.ElementType(), nil
+		case ty.IsTupleType():
+			switch etys := ty.TupleElementTypes(); len(etys) {
+			case 0:
+				return cty.DynamicPseudoType, nil
+			case 1:
+				return etys[0], nil
+			}
+			return cty.NilType, function.NewArgErrorf(0, "must be a list, set, or tuple value with either zero or one elements")
+		}
+		return cty.NilType, function.NewArgErrorf(0, "must be a list, set, or tuple value with either zero or one elements")
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		switch args[0].LengthInt() {
+		case 0:
+			return cty.NullVal(retType), nil
+		case 1:
+			it := args[0].ElementIterator()
+			it.Next()
+			_, v := it.Element()
+			return v, nil
+		}
+		return cty.NilVal, function.NewArgErrorf(0, "must be a list, set, or tuple value with either zero or one elements")
+	},
+})
+
+// sumFunc is the Terraform `sum` function.
+var sumFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		ty := args[0].Type()
+		if !ty.IsListType() && !ty.IsSetType() && !ty.IsTupleType() {
+			return cty.NilVal, function.NewArgErrorf(0, "cannot sum noniterable")
+		}
+		if args[0].LengthInt() == 0 {
+			return cty.NilVal, function.NewArgErrorf(0, "cannot sum an empty list")
+		}
+		sum := cty.Zero
+		for it := args[0].ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			if v.IsNull() {
+				return cty.NilVal, function.NewArgErrorf(0, "argument must be list, set, or tuple of number values")
+			}
+			n, err := convert.Convert(v, cty.Number)
+			if err != nil {
+				return cty.NilVal, function.NewArgErrorf(0, "argument must be list, set, or tuple of number values")
+			}
+			sum = sum.Add(n)
+		}
+		return sum, nil
+	},
+})
+
+// matchKeysFunc is the Terraform `matchkeys` function.
+var matchKeysFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "values", Type: cty.List(cty.DynamicPseudoType)},
+		{Name: "keys", Type: cty.List(cty.DynamicPseudoType)},
+		{Name: "searchset", Type: cty.List(cty.DynamicPseudoType)},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if args[0].LengthInt() != args[1].LengthInt() {
+			return cty.NilVal, function.NewArgErrorf(1, "length of keys and values should be equal")
+		}
+		values := args[0].AsValueSlice()
+		var out []cty.Value
+		for i, k := range args[1].AsValueSlice() {
+			for it := args[2].ElementIterator(); it.Next(); {
+				_, s := it.Element()
+				if eq := k.Equals(s); eq.IsKnown() && eq.True() {
+					out = append(out, values[i])
+					break
+				}
+			}
+		}
+		if len(out) == 0 {
+			return cty.ListValEmpty(retType.ElementType()), nil
+		}
+		return cty.ListVal(out), nil
+	},
+})
+
+// transposeFunc is the Terraform `transpose` function.
+var transposeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "values", Type: cty.Map(cty.List(cty.String))},
+	},
+	Type: function.StaticReturnType(cty.Map(cty.List(cty.String))),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		transposed := make(map[string][]cty.Value)
+		for it := args[0].ElementIterator(); it.Next(); {
+			k, list := it.Element()
+			if list.IsNull() {
+				return cty.NilVal, function.NewArgErrorf(0, "input must not contain null list")
+			}
+			for _, v := range list.AsValueSlice() {
+				if v.IsNull() {
+					return cty.NilVal, function.NewArgErrorf(0, "input list must not contain null string")
+				}
+				transposed[v.AsString()] = append(transposed[v.AsString()], k)
+			}
+		}
+		if len(transposed) == 0 {
+			return cty.MapValEmpty(cty.List(cty.String)), nil
+		}
+		out := make(map[string]cty.Value, len(transposed))
+		for k, keys := range transposed {
+			out[k] = cty.ListVal(keys)
+		}
+		return cty.MapVal(out), nil
+	},
+})
+
+// replaceFunc is the Terraform `replace` function, which replaces the matches of a regular
+// expression when the substring is wrapped in slashes, e.g. `replace(s, "/[0-9]+/", "n")`.
+var replaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "substr", Type: cty.String},
+		{Name: "replace", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		str, substr, replace := args[0].AsString(), args[1].AsString(), args[2].AsString()
+		if len(substr) > 1 && substr[0] == '/' && substr[len(substr)-1] == '/' {
+			re, err := regexp.Compile(substr[1 : len(substr)-1])
+			if err != nil {
+				return cty.NilVal, function.NewArgError(1, err)
+			}
+			return cty.StringVal(re.ReplaceAllString(str, replace)), nil
+		}
+		return cty.StringVal(strings.ReplaceAll(str, substr, replace)), nil
+	},
+})
+
+// timeCmpFunc is the Terraform `timecmp` function, which compares two RFC 3339 timestamps.
+var timeCmpFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "timestamp_a", Type: cty.String},
+		{Name: "timestamp_b", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		a, err := time.Parse(time.RFC3339, args[0].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgError(0, err)
+		}
+		b, err := time.Parse(time.RFC3339, args[1].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgError(1, err)
+		}
+		return cty.NumberIntVal(int64(a.Compare(b))), nil
+	},
+})
+
+// base64EncodeFunc is the Terraform `base64encode` function.
+var base64EncodeFunc = makeStringFunc(func(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+})
+
+// base64DecodeFunc is the Terraform `base64decode` function.
+var base64DecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		decoded, err := base64.StdEncoding.DecodeString(args[0].AsString())
+		if err != nil {
+			return cty.NilVal, function.NewArgErrorf(0, "failed to decode base64 data: %s", err)
+		}
+		if !utf8.Valid(decoded) {
+			return cty.NilVal, function.NewArgErrorf(0, "the result of decoding the provided string is not valid UTF-8")
+		}
+		return cty.StringVal(string(decoded)), nil
+	},
+})
+
+// identityFunc stands for the `sensitive` and `nonsensitive` functions, sensitivity is not tracked here.
+var identityFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		return args[0], nil
+	},
+})
+
+// isSensitiveFunc is the `issensitive` function, no value is sensitive here.
+var isSensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+		return cty.False, nil
+	},
+})
+
+// makeStringFunc returns a function that maps a string to a string, e.g. `basename`.
+func makeStringFunc(f func(string) string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "str", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal(f(args[0].AsString())), nil
+		},
+	})
+}
+
+// makeStringTestFunc returns a function that tests a string against another, e.g. `startswith`.
+func makeStringTestFunc(f func(s, substr string) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "str", Type: cty.String},
+			{Name: "substr", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.BoolVal(f(args[0].AsString(), args[1].AsString())), nil
+		},
+	})
+}
+
+// makeHashFunc returns a function that hashes a string and encodes the digest, e.g. `sha256`.
+func makeHashFunc(newHash func() hash.Hash, encode func([]byte) string) function.Function {
+	return makeStringFunc(func(s string) string {
+		h := newHash()
+		h.Write([]byte(s))
+		return encode(h.Sum(nil))
+	})
+}
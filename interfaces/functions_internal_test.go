@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TestTerraformFunctions checks the Terraform functions that are not part of the cty standard library.
+func TestTerraformFunctions(t *testing.T) {
+	cases := []struct {
+		expr     string
+		expected cty.Value
+	}{
+		{expr: `one([])`, expected: cty.NullVal(cty.DynamicPseudoType)},
+		{expr: `one(["a"])`, expected: cty.StringVal("a")},
+		{expr: `one(toset(["a"]))`, expected: cty.StringVal("a")},
+		{expr: `sum([1, 2, 3.5])`, expected: cty.NumberFloatVal(6.5)},
+		{expr: `startswith("CanNotDelete", "Can")`, expected: cty.True},
+		{expr: `endswith("CanNotDelete", "Can")`, expected: cty.False},
+		{expr: `strcontains("CanNotDelete", "Not")`, expected: cty.True},
+		{expr: `matchkeys(["a", "b", "c"], ["x", "y", "x"], ["x"])`, expected: cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("c")})},
+		{expr: `transpose({ a = ["1", "2"], b = ["2"] })`, expected: cty.MapVal(map[string]cty.Value{
+			"1": cty.ListVal([]cty.Value{cty.StringVal("a")}),
+			"2": cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		})},
+		{expr: `replace("a1b22", "/[0-9]+/", "n")`, expected: cty.StringVal("anbn")},
+		{expr: `replace("a.b", ".", "-")`, expected: cty.StringVal("a-b")},
+		{expr: `base64decode(base64encode("avm"))`, expected: cty.StringVal("avm")},
+		{expr: `sha256("")`, expected: cty.StringVal("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")},
+		{expr: `basename("/a/b.tf")`, expected: cty.StringVal("b.tf")},
+		{expr: `timecmp("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z")`, expected: cty.NumberIntVal(-1)},
+		{expr: `nonsensitive(sensitive("a"))`, expected: cty.StringVal("a")},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.expr), "test.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			val, diags := expr.Value(newEvalContext(nil))
+			require.False(t, diags.HasErrors(), diags.Error())
+			assert.True(t, tc.expected.RawEquals(val), "expected %#v, got %#v", tc.expected, val)
+		})
+	}
+}
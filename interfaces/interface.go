@@ -9,11 +9,20 @@ import (
 // with additional information for use in TFLint.
 type AvmInterface struct {
 	varcheck.VarCheck
-	RuleName      string          // RuleName of the interface, also the name of the variable to check.
-	VarTypeString string          // The variable type value as a sting.
-	RuleEnabled   bool            // Whether the rule is enabled by default.
-	RuleLink      string          // RuleLink to the interface specification.
-	RuleSeverity  tflint.Severity // Severity of the interface.
+	RuleName      string                // RuleName of the interface, also the name of the variable to check.
+	VarTypeString string                // The variable type value as a sting.
+	RuleEnabled   bool                  // Whether the rule is enabled by default.
+	RuleLink      string                // RuleLink to the interface specification.
+	RuleSeverity  tflint.Severity       // Severity of the interface.
+	Validations   []InterfaceValidation // Validations the variable must declare.
+	Revision      string                // Name of the revision of the interface specification.
+	EffectiveDate string                // Date the revision became effective, as YYYY-MM-DD. Empty if it always was.
+	// DeprecatedUntil is the last date, as YYYY-MM-DD, a previous revision is accepted with a warning.
+	DeprecatedUntil   string
+	PreviousRevisions []AvmInterface // Previous revisions of the interface, newest first.
+	// AllowExtensions is whether the variable type may add optional attributes to the interface type.
+	// The extensions are reported as warnings rather than errors.
+	AllowExtensions bool
 }
 
 // StringToTypeConstraintWithDefaults converts a string to a TypeConstraintWithDefaults.
@@ -2,8 +2,11 @@ package interfaces
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Azure/tflint-ruleset-avm/common"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/matt-FFFFFF/tfvarcheck/check"
 	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
@@ -23,7 +26,6 @@ var variableBodySchema = &hclext.BodySchema{
 					{Name: "default"},
 					{Name: "nullable"},
 				},
-				// We do not do anything with the validation data at the moment.
 				Blocks: []hclext.BlockSchema{
 					{
 						Type: "validation",
@@ -47,13 +49,17 @@ var _ tflint.Rule = new(InterfaceVarCheckRule)
 // check for the correct usage of an interface.
 type InterfaceVarCheckRule struct {
 	tflint.DefaultRule
-	AvmInterface // This is the interface we are checking for.
+	AvmInterface            // This is the interface we are checking for.
+	selectedRevision string // The revision selected in the plugin config, the current revision if empty.
+	specVersion      string // The `spec_version` date of the plugin config, deprecation windows are checked at it. Today if empty.
+	allowExtensions  bool   // Whether optional attributes may be added to the interface type, see AvmInterface.AllowExtensions.
 }
 
 // NewVarCheckRuleFromAvmInterface returns a new rule with the given variable.
 func NewVarCheckRuleFromAvmInterface(ifce AvmInterface) *InterfaceVarCheckRule {
 	return &InterfaceVarCheckRule{
-		AvmInterface: ifce,
+		AvmInterface:    ifce,
+		allowExtensions: ifce.AllowExtensions,
 	}
 }
 
@@ -80,7 +86,23 @@ func (vcr *InterfaceVarCheckRule) Severity() tflint.Severity {
 // Check checks whether the module satisfies the interface.
 // It will search for a variable with the same name as the interface.
 // It will check the type, default value and nullable attributes.
+// If the interface has previous revisions, the variable is checked against the selected revision.
+// Attributes named after the interface inside the types of other variables are checked too.
 func (vcr *InterfaceVarCheckRule) Check(r tflint.Runner) error {
+	check := vcr.checkVariable
+	if len(vcr.PreviousRevisions) > 0 {
+		check = func(r tflint.Runner) error {
+			return vcr.checkRevisions(r, "variable", (*InterfaceVarCheckRule).checkVariable)
+		}
+	}
+	if err := check(r); err != nil {
+		return err
+	}
+	return vcr.checkNestedInterfaces(r)
+}
+
+// checkVariable checks the variable against the interface, ignoring previous revisions.
+func (vcr *InterfaceVarCheckRule) checkVariable(r tflint.Runner) error {
 	path, err := r.GetModulePath()
 	if err != nil {
 		return err
@@ -104,44 +126,49 @@ func (vcr *InterfaceVarCheckRule) Check(r tflint.Runner) error {
 			continue
 		}
 
-		typeAttr, c := CheckWithReturnValue(NewChecker(), getAttr(vcr, r, b, "type"))
+		typeAttr, c := CheckWithReturnValue(NewChecker(), getAttr(vcr, r, b, "type", insertAttributeFix(r, b, "type", vcr.VarTypeString)))
 		var defaultAttr *hclext.Attribute
 		if vcr.Default.IsKnown() {
-			defaultAttr, c = CheckWithReturnValue(c, getAttr(vcr, r, b, "default"))
+			defaultAttr, c = CheckWithReturnValue(c, getAttr(vcr, r, b, "default", insertValueAttributeFix(r, b, "default", vcr.Default)))
 		} else {
 			c = c.Check(attributeNotExist(vcr, r, b, "default"))
 		}
 		if c = c.Check(checkVarType(vcr, r, typeAttr)).
 			Check(checkDefaultValue(vcr, r, b, defaultAttr)).
-			Check(checkNullableValue(vcr, r, b)); c.err != nil {
+			Check(checkNullableValue(vcr, r, b)).
+			Check(checkValidations(vcr, r, b)); c.err != nil {
 			return c.err
 		}
-		// TODO: Check validation rules.
 		return nil
 	}
 	return nil
 }
 
+// attributeNotExist returns a function that checks that the attribute is not declared in the block.
+// The check continues after the issue, so that a single fix run removes the attribute and fixes the other issues.
 func attributeNotExist(vcr *InterfaceVarCheckRule, r tflint.Runner, b *hclext.Block, attrName string) func() (bool, error) {
 	return func() (bool, error) {
-		_, exist := b.Body.Attributes[attrName]
+		attr, exist := b.Body.Attributes[attrName]
 		if exist {
-			return false, r.EmitIssue(vcr, fmt.Sprintf("`%s` %s should not be declared", b.Labels[0], attrName), b.DefRange)
+			return true, r.EmitIssueWithFix(vcr, fmt.Sprintf("`%s` %s should not be declared", b.Labels[0], attrName), b.DefRange, removeAttributeFix(attr))
 		}
 		return true, nil
 	}
 }
 
 // getAttr returns a function that will return the attribute from a given hcl block.
+// The fix function is offered when the attribute is missing. The check continues with a nil attribute,
+// so that a single fix run fixes the other issues too, the checks of the attribute itself are skipped.
 // It is designed to be used with the CheckWithReturnValue function.
-func getAttr(rule tflint.Rule, r tflint.Runner, b *hclext.Block, attrName string) func() (*hclext.Attribute, bool, error) {
+func getAttr(rule tflint.Rule, r tflint.Runner, b *hclext.Block, attrName string, fix func(tflint.Fixer) error) func() (*hclext.Attribute, bool, error) {
 	return func() (*hclext.Attribute, bool, error) {
 		attr, exists := b.Body.Attributes[attrName]
 		if !exists {
-			return attr, false, r.EmitIssue(
+			return attr, true, r.EmitIssueWithFix(
 				rule,
 				fmt.Sprintf("`%s` %s not declared", b.Labels[0], attrName),
 				b.DefRange,
+				fix,
 			)
 		}
 		return attr, true, nil
@@ -173,25 +200,63 @@ func checkNullableValue(vcr *InterfaceVarCheckRule, r tflint.Runner, b *hclext.B
 		if nullableAttr != nil {
 			rg = nullableAttr.Range
 		}
-		return false, r.EmitIssue(vcr, msg, rg)
+		var fix func(tflint.Fixer) error
+		switch {
+		case vcr.Nullable:
+			fix = removeAttributeFix(nullableAttr)
+		case nullableAttr != nil:
+			fix = replaceExprFix(nullableAttr, "false")
+		default:
+			fix = insertAttributeFix(r, b, "nullable", "false")
+		}
+		return false, r.EmitIssueWithFix(vcr, msg, rg, fix)
 	}
 }
 
 // checkVarType checks if the type of the variable is correct.
+// A missing type is reported by getAttr.
 // It is designed to be supplied to the Checker.Check() function.
 func checkVarType(vcr *InterfaceVarCheckRule, r tflint.Runner, typeAttr *hclext.Attribute) func() (bool, error) {
 	return func() (bool, error) {
+		if typeAttr == nil {
+			return true, nil
+		}
 		// Check if the type interface is correct.
 		gotType, diags := varcheck.NewTypeConstraintWithDefaultsFromExp(typeAttr.Expr)
 		if diags.HasErrors() {
 			return false, diags
 		}
-		if eq := check.EqualTypeConstraints(gotType, vcr.TypeConstraintWithDefs); !eq {
-			return true, r.EmitIssue(vcr,
+		if eq := check.EqualTypeConstraints(gotType, vcr.TypeConstraintWithDefs); eq {
+			return true, nil
+		}
+		diffs := DiffTypeConstraints(vcr.RuleName, gotType, vcr.TypeConstraintWithDefs)
+		if len(diffs) == 0 {
+			// The types are structurally equal but differ in a way we do not describe,
+			// so fall back to showing the whole expected type.
+			return true, r.EmitIssueWithFix(vcr,
 				fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", vcr.VarTypeString),
 				typeAttr.Range,
+				replaceExprFix(typeAttr, vcr.VarTypeString),
 			)
 		}
+		for _, diff := range diffs {
+			if diff.Extension && vcr.allowExtensions {
+				if err := r.EmitIssue(common.WithSeverity(vcr, tflint.WARNING),
+					extensionMessage("variable type", diff),
+					typeExprRange(typeAttr.Expr, diff.Path),
+				); err != nil {
+					return false, err
+				}
+				continue
+			}
+			if err := r.EmitIssueWithFix(vcr,
+				fmt.Sprintf("variable type does not comply with the interface specification: %s", diff),
+				typeExprRange(typeAttr.Expr, diff.Path),
+				replaceExprFix(typeAttr, vcr.VarTypeString),
+			); err != nil {
+				return false, err
+			}
+		}
 		return true, nil
 	}
 }
@@ -203,20 +268,40 @@ func checkDefaultValue(vcr *InterfaceVarCheckRule, r tflint.Runner, b *hclext.Bl
 		// Check if the default value is correct.
 		if !vcr.Default.IsKnown() {
 			if defaultAttr != nil {
-				return true, r.EmitIssue(
+				return true, r.EmitIssueWithFix(
 					vcr,
 					fmt.Sprintf("default value should not be set, see: %s", vcr.Link()),
 					defaultAttr.Range,
+					removeAttributeFix(defaultAttr),
 				)
 			}
 			return true, nil
 		}
-		defaultVal, _ := defaultAttr.Expr.Value(nil)
-		if !check.EqualCtyValue(defaultVal, vcr.Default) {
-			return true, r.EmitIssue(
+		if defaultAttr == nil {
+			// A missing default is reported by getAttr.
+			return true, nil
+		}
+		// Defaults may call functions, e.g. `tomap({})`, but must not refer to anything.
+		// JSON defaults are literal values, their strings are not templates.
+		var ctx *hcl.EvalContext
+		if _, ok := defaultAttr.Expr.(hclsyntax.Expression); ok {
+			ctx = newEvalContext(nil)
+		}
+		defaultVal, diags := defaultAttr.Expr.Value(ctx)
+		if diags.HasErrors() || !defaultVal.IsWhollyKnown() {
+			return true, r.EmitIssueWithFix(
 				vcr,
-				fmt.Sprintf("default value is not correct, see: %s", vcr.Link()),
+				fmt.Sprintf("default must be a constant expression, expected %s, see: %s", valueString(vcr.Default), vcr.Link()),
+				defaultAttr.Expr.Range(),
+				replaceValueFix(defaultAttr, vcr.Default),
+			)
+		}
+		if !vcr.sameDefault(defaultVal) {
+			return true, r.EmitIssueWithFix(
+				vcr,
+				fmt.Sprintf("default value is not correct, expected %s, got %s, see: %s", valueString(vcr.Default), valueString(defaultVal), vcr.Link()),
 				b.DefRange,
+				replaceValueFix(defaultAttr, vcr.Default),
 			)
 		}
 		return true, nil
@@ -266,3 +351,23 @@ func CheckWithReturnValue[TR any](c Checker, check func() (TR, bool, error)) (re
 		err:           err,
 	}
 }
+
+// extensionMessage describes an optional attribute that extends the interface type.
+func extensionMessage(what string, diff TypeDifference) string {
+	return fmt.Sprintf("%s extends the interface specification: %s is declared as %s, which is not part of the interface. "+
+		"The extension is compatible as the attribute is optional", what, strings.Join(diff.Path, "."), diff.Got)
+}
+
+// sameDefault returns whether the default value is the interface default once both are converted
+// to the interface type, as Terraform does, so that e.g. `tomap({})` is the same as `{}`.
+func (vcr *InterfaceVarCheckRule) sameDefault(got cty.Value) bool {
+	want, err := vcr.typedValue(vcr.Default)
+	if err != nil {
+		return check.EqualCtyValue(got, vcr.Default)
+	}
+	got, err = vcr.typedValue(got)
+	if err != nil {
+		return false
+	}
+	return got.Equals(want).True()
+}
@@ -0,0 +1,75 @@
+package interfaces
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The functions in this file return fix functions for use with EmitIssueWithFix.
+// Each fix only touches a single attribute of the variable block,
+// so that other attributes such as `description` and `validation` are preserved
+// and fixes emitted for the same block do not overlap.
+// Fixes are not supported for JSON syntax.
+
+// replaceExprFix returns a fix that replaces the expression of the attribute with the given text.
+func replaceExprFix(attr *hclext.Attribute, text string) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		if terraform.IsJSONFilename(attr.Range.Filename) {
+			return tflint.ErrFixNotSupported
+		}
+		return f.ReplaceText(attr.Expr.Range(), text)
+	}
+}
+
+// replaceValueFix returns a fix that replaces the expression of the attribute with the given value.
+func replaceValueFix(attr *hclext.Attribute, val cty.Value) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		return replaceExprFix(attr, f.ValueText(val))(f)
+	}
+}
+
+// removeAttributeFix returns a fix that removes the attribute from the block.
+func removeAttributeFix(attr *hclext.Attribute) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		if attr == nil {
+			return tflint.ErrFixNotSupported
+		}
+		return f.RemoveAttribute(attr.AsNative())
+	}
+}
+
+// insertAttributeFix returns a fix that inserts `name = text` at the start of the block body.
+func insertAttributeFix(r tflint.Runner, b *hclext.Block, name, text string) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		filename := b.DefRange.Filename
+		if terraform.IsJSONFilename(filename) {
+			return tflint.ErrFixNotSupported
+		}
+		file, err := r.GetFile(filename)
+		if err != nil {
+			return err
+		}
+		if file == nil {
+			return fmt.Errorf("file not found: %s", filename)
+		}
+		start := b.DefRange.End
+		idx := bytes.IndexByte(file.Bytes[start.Byte:], '{')
+		if idx < 0 {
+			return tflint.ErrFixNotSupported
+		}
+		openBrace := f.RangeTo(string(file.Bytes[start.Byte:start.Byte+idx+1]), filename, start)
+		return f.InsertTextAfter(openBrace, fmt.Sprintf("\n%s = %s", name, text))
+	}
+}
+
+// insertValueAttributeFix returns a fix that inserts `name = val` at the start of the block body.
+func insertValueAttributeFix(r tflint.Runner, b *hclext.Block, name string, val cty.Value) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		return insertAttributeFix(r, b, name, f.ValueText(val))(f)
+	}
+}
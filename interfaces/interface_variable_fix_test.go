@@ -0,0 +1,134 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestInterfaceVarCheckRuleFix(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Interface interfaces.AvmInterface
+		Content   string
+		Expected  string
+	}{
+		{
+			Name:      "incorrect type and default",
+			Interface: SimpleVar,
+			Content: `variable "simple" {
+  description = "The lock."
+  type = object({
+    kind = number
+  })
+  default  = {}
+  nullable = true
+
+  validation {
+    condition     = var.simple == null
+    error_message = "Must be null."
+  }
+}`,
+			Expected: `variable "simple" {
+  description = "The lock."
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = var.simple == null
+    error_message = "Must be null."
+  }
+}`,
+		},
+		{
+			Name:      "missing nullable and unwanted default",
+			Interface: SimpleVarNoDefault,
+			Content: `variable "simple" {
+  description = "The lock."
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+}`,
+			Expected: `variable "simple" {
+  nullable    = false
+  description = "The lock."
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+}`,
+		},
+		{
+			Name:      "missing nullable",
+			Interface: SimpleVarNoDefault,
+			Content: `variable "simple" {
+  description = "The lock."
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+}`,
+			Expected: `variable "simple" {
+  nullable    = false
+  description = "The lock."
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+}`,
+		},
+		{
+			Name:      "missing default",
+			Interface: SimpleVar,
+			Content: `variable "simple" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+}`,
+			Expected: `variable "simple" {
+  default = null
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+}`,
+		},
+		{
+			Name:      "missing type and nullable",
+			Interface: SimpleVarNoDefault,
+			Content: `variable "simple" {
+  description = "The lock."
+}`,
+			Expected: `variable "simple" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  nullable    = false
+  description = "The lock."
+}`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			rule := interfaces.NewVarCheckRuleFromAvmInterface(tc.Interface)
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertChanges(t, map[string]string{"variables.tf": tc.Expected}, runner.Changes())
+		})
+	}
+}
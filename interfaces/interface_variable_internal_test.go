@@ -0,0 +1,103 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// issueRecorder records the issues emitted with a fix, without applying the fix.
+type issueRecorder struct {
+	tflint.Runner
+	messages []string
+}
+
+func (r *issueRecorder) EmitIssueWithFix(_ tflint.Rule, message string, _ hcl.Range, _ func(tflint.Fixer) error) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+// TestCheckDefaultValue checks the default values that Terraform rejects before the test runner
+// can load them, i.e. function calls and references, on expressions parsed directly.
+func TestCheckDefaultValue(t *testing.T) {
+	link := RoleAssignments.RuleLink
+	cases := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{
+			name: "function call with the expected value",
+			expr: `tomap({})`,
+		},
+		{
+			name:     "function call with another value",
+			expr:     `tomap({ a = { role_definition_id_or_name = "Reader", principal_id = "id" } })`,
+			expected: `default value is not correct, expected {}, got { a = { principal_id = "id", role_definition_id_or_name = "Reader" } }, see: ` + link,
+		},
+		{
+			name:     "reference",
+			expr:     `var.defaults`,
+			expected: "default must be a constant expression, expected {}, see: " + link,
+		},
+		{
+			name:     "unknown function",
+			expr:     `file("defaults.json")`,
+			expected: "default must be a constant expression, expected {}, see: " + link,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.expr), "variables.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			attr := &hclext.Attribute{Name: "default", Expr: expr, Range: expr.Range()}
+			block := &hclext.Block{Type: "variable", Labels: []string{"role_assignments"}, DefRange: hcl.Range{Filename: "variables.tf"}}
+			runner := &issueRecorder{}
+
+			_, err := checkDefaultValue(NewVarCheckRuleFromAvmInterface(RoleAssignments), runner, block, attr)()
+			require.NoError(t, err)
+
+			if tc.expected == "" {
+				assert.Empty(t, runner.messages)
+				return
+			}
+			assert.Equal(t, []string{tc.expected}, runner.messages)
+		})
+	}
+}
+
+func TestSameDefault(t *testing.T) {
+	cases := []struct {
+		name     string
+		ifce     AvmInterface
+		expr     string
+		expected bool
+	}{
+		{name: "function call", ifce: RoleAssignments, expr: `tomap({})`, expected: true},
+		{name: "optional attribute defaults applied", ifce: ManagedIdentities, expr: `{ system_assigned = false }`, expected: true},
+		{name: "different value", ifce: ManagedIdentities, expr: `{ system_assigned = true }`, expected: false},
+		{name: "null instead of an empty map", ifce: RoleAssignments, expr: `null`, expected: false},
+		{name: "value of another type", ifce: RoleAssignments, expr: `"none"`, expected: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.expr), "variables.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors(), diags.Error())
+			val, diags := expr.Value(newEvalContext(nil))
+			require.False(t, diags.HasErrors(), diags.Error())
+
+			assert.Equal(t, tc.expected, NewVarCheckRuleFromAvmInterface(tc.ifce).sameDefault(val))
+		})
+	}
+}
@@ -5,17 +5,45 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-var Rules = []tflint.Rule{
-	NewVarCheckRuleFromAvmInterface(CustomerManagedKey),
-	NewVarCheckRuleFromAvmInterface(DiagnosticSettings),
-	NewVarCheckRuleFromAvmInterface(Location),
-	NewVarCheckRuleFromAvmInterface(Lock),
-	NewVarCheckRuleFromAvmInterface(ManagedIdentities),
-	NewVarCheckRuleFromAvmInterface(RoleAssignments),
-	NewVarCheckRuleFromAvmInterface(Tags),
-	func() tflint.Rule {
-		return common.NewEitherCheckRule("private_endpoints", true, tflint.ERROR,
+// Interfaces are all the interfaces of the specification.
+var Interfaces = []AvmInterface{
+	CustomerManagedKey,
+	DiagnosticSettings,
+	EnableTelemetry,
+	Location,
+	Lock,
+	ManagedIdentities,
+	PrivateEndpoints,
+	PrivateEndpointsWithSubresourceName,
+	RoleAssignments,
+	Tags,
+}
+
+// Rules are the rules of the interfaces category.
+var Rules = NewRules()
+
+// NewRules returns new instances of the rules of the interfaces category,
+// so that the rules of each rule set are configured separately.
+func NewRules() []tflint.Rule {
+	return []tflint.Rule{
+		NewVarCheckRuleFromAvmInterface(CustomerManagedKey),
+		NewVarCheckRuleFromAvmInterface(DiagnosticSettings),
+		NewVarCheckRuleFromAvmInterface(EnableTelemetry),
+		NewVarCheckRuleFromAvmInterface(Location),
+		NewVarCheckRuleFromAvmInterface(Lock),
+		NewVarCheckRuleFromAvmInterface(ManagedIdentities),
+		NewVarCheckRuleFromAvmInterface(RoleAssignments),
+		NewVarCheckRuleFromAvmInterface(Tags),
+		NewInterfaceUsageRule(LockUsage),
+		NewInterfaceUsageRule(RoleAssignmentsUsage),
+		NewInterfaceUsageRule(DiagnosticSettingsUsage),
+		NewInterfaceUsageRule(ManagedIdentitiesUsage),
+		NewInterfaceUsageRule(CustomerManagedKeyUsage),
+		NewInterfaceUsageRule(PrivateEndpointsUsage),
+		NewInterfaceUsageRule(TelemetryUsage),
+		NewRequiredInterfacesRule(),
+		common.NewAnyOfRule("private_endpoints", true, tflint.ERROR,
 			NewVarCheckRuleFromAvmInterface(PrivateEndpoints),
-			NewVarCheckRuleFromAvmInterface(PrivateEndpointsWithSubresourceName))
-	}(),
+			common.WithLabel(NewVarCheckRuleFromAvmInterface(PrivateEndpointsWithSubresourceName), "private_endpoints (subresource variant)")),
+	}
 }
@@ -1,7 +1,6 @@
 package interfaces_test
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/Azure/tflint-ruleset-avm/interfaces"
@@ -116,8 +115,8 @@ variable "complex" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(ComplexVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", complexVarTypeString),
-					Range:   hcl.Range{Filename: "variables.tf", Start: hcl.Pos{Line: 5, Column: 2}, End: hcl.Pos{Line: 33, Column: 5}},
+					Message: `variable type does not comply with the interface specification: complex.*.lock.name: expected optional(string, null), got optional(string, "foo")`,
+					Range:   hcl.Range{Filename: "variables.tf", Start: hcl.Pos{Line: 18, Column: 4}, End: hcl.Pos{Line: 18, Column: 34}},
 				},
 			},
 		},
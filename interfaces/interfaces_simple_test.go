@@ -78,6 +78,10 @@ variable "simple" {
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVarNoDefault),
 					Message: "`simple` default should not be declared",
 				},
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVarNoDefault),
+					Message: "nullable should be set to false",
+				},
 			},
 		},
 		{
@@ -174,11 +178,11 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", simpleVarTypeString),
+					Message: "variable type does not comply with the interface specification: simple.unwanted: unexpected attribute string",
 					Range: hcl.Range{
 						Filename: "variables.tf",
-						Start:    hcl.Pos{Line: 4, Column: 2},
-						End:      hcl.Pos{Line: 8, Column: 4},
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 20},
 					},
 				},
 			},
@@ -197,13 +201,22 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", simpleVarTypeString),
+					Message: "variable type does not comply with the interface specification: simple.kind: missing attribute, expected string",
 					Range: hcl.Range{
 						Filename: "variables.tf",
-						Start:    hcl.Pos{Line: 4, Column: 2},
+						Start:    hcl.Pos{Line: 4, Column: 9},
 						End:      hcl.Pos{Line: 8, Column: 4},
 					},
 				},
+				&helper.Issue{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
+					Message: "variable type does not comply with the interface specification: simple.unwanted: unexpected attribute string",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 20},
+					},
+				},
 			},
 		},
 		{
@@ -219,11 +232,11 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", simpleVarTypeString),
+					Message: "variable type does not comply with the interface specification: simple.kind: expected string, got number",
 					Range: hcl.Range{
 						Filename: "variables.tf",
-						Start:    hcl.Pos{Line: 4, Column: 2},
-						End:      hcl.Pos{Line: 7, Column: 4},
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 16},
 					},
 				},
 			},
@@ -241,11 +254,11 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", simpleVarTypeString),
+					Message: "variable type does not comply with the interface specification: simple.name: expected optional(string, null), got optional(number, null)",
 					Range: hcl.Range{
 						Filename: "variables.tf",
-						Start:    hcl.Pos{Line: 4, Column: 2},
-						End:      hcl.Pos{Line: 7, Column: 4},
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 32},
 					},
 				},
 			},
@@ -263,11 +276,11 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("variable type does not comply with the interface specification:\n\n%s", simpleVarTypeString),
+					Message: "variable type does not comply with the interface specification: simple.name: expected optional(string, null), got optional(string, \"\")",
 					Range: hcl.Range{
 						Filename: "variables.tf",
-						Start:    hcl.Pos{Line: 4, Column: 2},
-						End:      hcl.Pos{Line: 7, Column: 4},
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 30},
 					},
 				},
 			},
@@ -308,7 +321,7 @@ variable "simple" {
 			Expected: helper.Issues{
 				&helper.Issue{
 					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(SimpleVar),
-					Message: fmt.Sprintf("default value is not correct, see: %s", SimpleVar.RuleLink),
+					Message: fmt.Sprintf("default value is not correct, expected null, got { kind = \"CanNotDelete\" }, see: %s", SimpleVar.RuleLink),
 					Range: hcl.Range{
 						Filename: "variables.tf",
 						Start:    hcl.Pos{Line: 2, Column: 1},
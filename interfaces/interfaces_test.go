@@ -26,5 +26,16 @@ func toTerraformVarType(i interfaces.AvmInterface) string {
 	if !i.Nullable {
 		varBody.SetAttributeValue("nullable", cty.False)
 	}
+	for _, v := range i.Validations {
+		validationBody := varBody.AppendNewBlock("validation", nil).Body()
+		validationBody.SetAttributeRaw("condition", hclwrite.Tokens{
+			&hclwrite.Token{
+				Type:         hclsyntax.TokenStringLit,
+				Bytes:        []byte(v.Condition),
+				SpacesBefore: 1,
+			},
+		})
+		validationBody.SetAttributeValue("error_message", cty.StringVal(v.Description))
+	}
 	return string(f.Bytes())
 }
@@ -1,20 +1,6 @@
 package interfaces
 
-import (
-	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
-	"github.com/zclconf/go-cty/cty"
-)
+var LocationTypeString = Location.VarTypeString
 
-var LocationTypeString = `string`
-
-var locationType = StringToTypeConstraintWithDefaults(LocationTypeString)
-
-var Location = AvmInterface{
-	VarCheck:      varcheck.NewVarCheck(locationType, cty.UnknownVal(cty.String), false),
-	RuleName:      "location",
-	VarTypeString: LocationTypeString,
-	RuleEnabled:   true,
-	RuleLink:      "",
-	RuleSeverity:  tflint.ERROR,
-}
+// Location is the location interface, see spec/location.hcl.
+var Location = specInterface("location")
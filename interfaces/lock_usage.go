@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// LockUsage checks that the `lock` variable is consumed by a management lock on the primary resource.
+var LockUsage = &InterfaceUsageRule{
+	RuleName:        "lock_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#resource-locks",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "lock",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkLockUsage,
+}
+
+// checkLockUsage checks the `azurerm_management_lock` and `azapi_resource` locks that refer to `var.lock`.
+// Lock resources that do not refer to `var.lock` at all are ignored, as they may lock something else.
+func checkLockUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	azurermLocks := m.resourcesOfType("azurerm_management_lock")
+	azapiLocks := m.azapiResourcesOfType("Microsoft.Authorization/locks")
+
+	locks := append(azurermLocks, azapiLocks...)
+	found := false
+	for _, b := range locks {
+		if !m.blockRefersTo(b, "var", "lock") {
+			continue
+		}
+		found = true
+
+		levelAttr, scopeAttr := "lock_level", "scope"
+		if b.Labels[0] == "azapi_resource" {
+			levelAttr, scopeAttr = "body", "parent_id"
+		}
+		name := fmt.Sprintf("%s.%s", b.Labels[0], b.Labels[1])
+
+		if !m.repetitionRefersTo(b, "var", "lock") {
+			if err := r.EmitIssue(rule, fmt.Sprintf("`%s` must set `count` or `for_each` from `var.lock`", name), b.DefRange()); err != nil {
+				return err
+			}
+		}
+		if !m.attrRefersTo(b, levelAttr, "var", "lock", "kind") {
+			if err := r.EmitIssue(rule, fmt.Sprintf("`%s` must set the lock level from `var.lock.kind` in `%s`", name, levelAttr), b.DefRange()); err != nil {
+				return err
+			}
+		}
+		if !m.attrRefersToPrimaryResource(b, scopeAttr, locks) {
+			if err := r.EmitIssue(rule, fmt.Sprintf("`%s` must set `%s` to the primary resource of the module", name, scopeAttr), b.DefRange()); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return r.EmitIssue(rule, "`lock` variable is declared but no `azurerm_management_lock` or `Microsoft.Authorization/locks` resource uses it", variable.DefRange())
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestLockUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.Lock)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "azurerm lock",
+			Content: `resource "azurerm_key_vault" "this" {}
+
+resource "azurerm_management_lock" "this" {
+  count = var.lock != null ? 1 : 0
+
+  lock_level = var.lock.kind
+  name       = coalesce(var.lock.name, "lock-${var.lock.kind}")
+  scope      = azurerm_key_vault.this.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "azapi lock through a local",
+			Content: `resource "azapi_resource" "this" {}
+
+locals {
+  lock_level = var.lock.kind
+}
+
+resource "azapi_resource" "lock" {
+  for_each = var.lock != null ? { lock = var.lock } : {}
+
+  type      = "Microsoft.Authorization/locks@2020-05-01"
+  name      = "lock"
+  parent_id = azapi_resource.this.id
+  body = {
+    properties = {
+      level = local.lock_level
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "no lock resource",
+			Content: `resource "azurerm_key_vault" "this" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.LockUsage,
+					Message: "`lock` variable is declared but no `azurerm_management_lock` or `Microsoft.Authorization/locks` resource uses it",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "lock not wired",
+			Content: `resource "azurerm_key_vault" "this" {}
+
+resource "azurerm_management_lock" "this" {
+  lock_level = "CanNotDelete"
+  name       = var.lock.name
+  scope      = var.scope
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.LockUsage,
+					Message: "`azurerm_management_lock.this` must set `count` or `for_each` from `var.lock`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 42},
+					},
+				},
+				{
+					Rule:    interfaces.LockUsage,
+					Message: "`azurerm_management_lock.this` must set the lock level from `var.lock.kind` in `lock_level`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 42},
+					},
+				},
+				{
+					Rule:    interfaces.LockUsage,
+					Message: "`azurerm_management_lock.this` must set `scope` to the primary resource of the module",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 42},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Content,
+				"variables.tf": variable,
+			})
+
+			if err := interfaces.LockUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+// TestNewRulesUsageInstances checks that each set of rules has its own usage rules.
+func TestNewRulesUsageInstances(t *testing.T) {
+	find := func(rules []tflint.Rule) tflint.Rule {
+		for _, r := range rules {
+			if r.Name() == "lock_usage" {
+				return r
+			}
+		}
+		return nil
+	}
+	first, second := find(interfaces.NewRules()), find(interfaces.NewRules())
+	assert.NotNil(t, first)
+	assert.NotSame(t, first, second)
+	assert.NotSame(t, interfaces.LockUsage, first)
+}
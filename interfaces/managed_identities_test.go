@@ -7,7 +7,7 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
-// TestDiagnosticSettingsInterface tests the diagnostic settings interface.
+// TestManagedIdentitiesInterface tests the managed identities interface.
 func TestManagedIdentitiesInterface(t *testing.T) {
 	cases := []struct {
 		Name     string
@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// ManagedIdentitiesUsage checks that the `managed_identities` variable is consumed by the identity of the primary resource.
+var ManagedIdentitiesUsage = &InterfaceUsageRule{
+	RuleName:        "managed_identities_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#managed-identities",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "managed_identities",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkManagedIdentitiesUsage,
+}
+
+// checkManagedIdentitiesUsage checks the `identity` blocks, static or dynamic, of the primary resources
+// that refer to `var.managed_identities`.
+// When the module has no resource named `this`, the identity blocks of every resource are considered.
+func checkManagedIdentitiesUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	resources := m.primaryResources(nil)
+	if len(resources) == 0 {
+		resources = m.resources
+	}
+
+	found := false
+	for _, b := range resources {
+		for _, ib := range identityBlocks(b) {
+			if !m.blockRefersTo(ib, "var", "managed_identities") {
+				continue
+			}
+			found = true
+			for _, field := range []string{"system_assigned", "user_assigned_resource_ids"} {
+				if m.blockRefersTo(ib, "var", "managed_identities", field) {
+					continue
+				}
+				msg := fmt.Sprintf("`%s.%s` `identity` block must be computed from `var.managed_identities.%s`", b.Labels[0], b.Labels[1], field)
+				if err := r.EmitIssue(rule, msg, ib.DefRange()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if !found {
+		return r.EmitIssue(rule, "`managed_identities` variable is declared but no `identity` block of the primary resource uses it", variable.DefRange())
+	}
+	return nil
+}
+
+// identityBlocks returns the `identity` blocks and the `dynamic "identity"` blocks of the resource.
+func identityBlocks(b *hclsyntax.Block) []*hclsyntax.Block {
+	return append(blocksOfType(b, "identity"), dynamicBlocks(b, "identity")...)
+}
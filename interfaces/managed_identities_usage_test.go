@@ -0,0 +1,110 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestManagedIdentitiesUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.ManagedIdentities)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "dynamic identity through a local",
+			Content: `locals {
+  managed_identities = {
+    system_assigned_user_assigned = (var.managed_identities.system_assigned || length(var.managed_identities.user_assigned_resource_ids) > 0) ? {
+      this = {
+        type                       = var.managed_identities.system_assigned && length(var.managed_identities.user_assigned_resource_ids) > 0 ? "SystemAssigned, UserAssigned" : length(var.managed_identities.user_assigned_resource_ids) > 0 ? "UserAssigned" : "SystemAssigned"
+        user_assigned_resource_ids = var.managed_identities.user_assigned_resource_ids
+      }
+    } : {}
+  }
+}
+
+resource "azurerm_key_vault" "this" {
+  dynamic "identity" {
+    for_each = local.managed_identities.system_assigned_user_assigned
+
+    content {
+      type         = identity.value.type
+      identity_ids = identity.value.user_assigned_resource_ids
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "azapi identity",
+			Content: `resource "azapi_resource" "this" {
+  identity {
+    type         = var.managed_identities.system_assigned ? "SystemAssigned" : "None"
+    identity_ids = var.managed_identities.user_assigned_resource_ids
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "user assigned identities ignored",
+			Content: `resource "azurerm_key_vault" "this" {
+  dynamic "identity" {
+    for_each = var.managed_identities.system_assigned ? [1] : []
+
+    content {
+      type = "SystemAssigned"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.ManagedIdentitiesUsage,
+					Message: "`azurerm_key_vault.this` `identity` block must be computed from `var.managed_identities.user_assigned_resource_ids`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3},
+						End:      hcl.Pos{Line: 2, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name:    "not used",
+			Content: `resource "azurerm_key_vault" "this" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.ManagedIdentitiesUsage,
+					Message: "`managed_identities` variable is declared but no `identity` block of the primary resource uses it",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 30},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Content,
+				"variables.tf": variable,
+			})
+
+			if err := interfaces.ManagedIdentitiesUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
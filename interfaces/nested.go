@@ -0,0 +1,145 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// nestedAttr is an object attribute named after an interface, found inside the type of another variable.
+type nestedAttr struct {
+	path     []string // Path to the attribute, e.g. ["containers", "*", "lock"].
+	ty       cty.Type
+	defs     *typeexpr.Defaults // The defaults of the optional attributes nested in the attribute.
+	optional bool
+	def      *cty.Value // The default of the attribute, if it is optional and has one.
+}
+
+// checkNestedInterfaces checks the attributes named after the interface in the type constraints of the other variables,
+// e.g. `lock` in `containers = map(object({ lock = optional(object({...}), null) }))`.
+// The variables of the built-in interfaces are skipped, their own rule checks them.
+// Like the variable, each attribute may comply with a previous revision inside its deprecation window.
+func (vcr *InterfaceVarCheckRule) checkNestedInterfaces(r tflint.Runner) error {
+	path, err := r.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+	body, err := r.GetModuleContent(
+		variableBodySchema,
+		&tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, b := range body.Blocks {
+		name := b.Labels[0]
+		typeAttr, ok := b.Body.Attributes["type"]
+		if !ok || name == vcr.RuleName || IsKnownInterface(name) {
+			continue
+		}
+		ty, diags := varcheck.NewTypeConstraintWithDefaultsFromExp(typeAttr.Expr)
+		if diags.HasErrors() {
+			// An invalid type constraint is reported by Terraform.
+			continue
+		}
+		for _, attr := range findNestedAttrs([]string{name}, ty.Type, ty.Default, vcr.RuleName) {
+			check := func(rule *InterfaceVarCheckRule, r tflint.Runner) error {
+				return checkNestedAttr(rule, r, typeAttr, attr)
+			}
+			subject := fmt.Sprintf("nested `%s` interface `%s`", vcr.RuleName, strings.Join(attr.path, "."))
+			if err := vcr.checkRevisions(r, subject, check); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkNestedAttr checks the type of the nested attribute, and that it is optional with the default
+// of the interface, if the interface has one, against the revision of the interface held by the rule.
+func checkNestedAttr(vcr *InterfaceVarCheckRule, r tflint.Runner, typeAttr *hclext.Attribute, attr nestedAttr) error {
+	want := vcr.AvmInterface
+	for _, diff := range diffTypes(attr.path, attr.ty, want.TypeConstraintWithDefs.Type, attr.defs, want.TypeConstraintWithDefs.Default) {
+		if diff.Extension && vcr.allowExtensions {
+			if err := r.EmitIssue(common.WithSeverity(vcr, tflint.WARNING),
+				extensionMessage(fmt.Sprintf("nested `%s` interface", vcr.RuleName), diff),
+				typeExprRange(typeAttr.Expr, diff.Path),
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.EmitIssue(vcr,
+			fmt.Sprintf("nested `%s` interface does not comply with the interface specification: %s", vcr.RuleName, diff),
+			typeExprRange(typeAttr.Expr, diff.Path),
+		); err != nil {
+			return err
+		}
+	}
+	if !want.Default.IsKnown() || (attr.optional && sameNestedDefault(attr, want.Default)) {
+		return nil
+	}
+	return r.EmitIssue(vcr,
+		fmt.Sprintf("nested `%s` interface does not comply with the interface specification: %s: expected optional(%s, %s)",
+			vcr.RuleName, strings.Join(attr.path, "."), typeName(want.TypeConstraintWithDefs.Type), valueString(want.Default)),
+		typeExprRange(typeAttr.Expr, attr.path),
+	)
+}
+
+// sameNestedDefault returns whether the default of the optional attribute is the interface default.
+// An optional attribute without a default is null when omitted.
+func sameNestedDefault(attr nestedAttr, want cty.Value) bool {
+	got := cty.NullVal(attr.ty)
+	if attr.def != nil {
+		got = *attr.def
+	}
+	want, err := convert.Convert(want, attr.ty)
+	if err != nil {
+		return false
+	}
+	return got.Equals(want).True()
+}
+
+// findNestedAttrs returns the object attributes with the given name inside the type, sorted by path.
+// The attributes found are not walked into.
+func findNestedAttrs(path []string, ty cty.Type, defs *typeexpr.Defaults, name string) []nestedAttr {
+	switch {
+	case ty.IsObjectType():
+		names := make([]string, 0, len(ty.AttributeTypes()))
+		for n := range ty.AttributeTypes() {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		var attrs []nestedAttr
+		for _, n := range names {
+			attrPath := append(slices.Clone(path), n)
+			if n != name {
+				attrs = append(attrs, findNestedAttrs(attrPath, ty.AttributeType(n), childDefaults(defs, n), name)...)
+				continue
+			}
+			attrs = append(attrs, nestedAttr{
+				path:     attrPath,
+				ty:       ty.AttributeType(n),
+				defs:     childDefaults(defs, n),
+				optional: ty.AttributeOptional(n),
+				def:      defaultValue(defs, n),
+			})
+		}
+		return attrs
+	case ty.IsCollectionType():
+		return findNestedAttrs(append(slices.Clone(path), collectionElementStep), ty.ElementType(), childDefaults(defs, ""), name)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package interfaces_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+// TestNestedInterfaces tests the interfaces nested in the types of other variables.
+func TestNestedInterfaces(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Interface interfaces.AvmInterface
+		Content   string
+		Expected  helper.Issues
+	}{
+		{
+			Name:      "correct nested lock",
+			Interface: interfaces.Lock,
+			Content: fmt.Sprintf(`
+variable "containers" {
+  type = map(object({
+    name = string
+    lock = optional(%s, null)
+  }))
+}`, interfaces.LockTypeString),
+			Expected: helper.Issues{},
+		},
+		{
+			Name:      "optional nested lock without default",
+			Interface: interfaces.Lock,
+			Content: fmt.Sprintf(`
+variable "containers" {
+  type = list(object({
+    lock = optional(%s)
+  }))
+}`, interfaces.LockTypeString),
+			Expected: helper.Issues{},
+		},
+		{
+			Name:      "incorrect nested lock type",
+			Interface: interfaces.Lock,
+			Content: `
+variable "containers" {
+  type = map(object({
+    lock = optional(object({
+      kind = number
+      name = optional(string, null)
+    }), null)
+  }))
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock),
+					Message: "nested `lock` interface does not comply with the interface specification: containers.*.lock.kind: expected string, got number",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 5, Column: 7},
+						End:      hcl.Pos{Line: 5, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name:      "required nested lock",
+			Interface: interfaces.Lock,
+			Content: fmt.Sprintf(`
+variable "settings" {
+  type = object({
+    lock = %s
+  })
+}`, interfaces.LockTypeString),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock),
+					Message: "nested `lock` interface does not comply with the interface specification: settings.lock: expected optional(object({...}), null)",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 7, Column: 3},
+					},
+				},
+			},
+		},
+		{
+			Name:      "incorrect nested role assignments default",
+			Interface: interfaces.RoleAssignments,
+			Content: fmt.Sprintf(`
+variable "containers" {
+  type = map(object({
+    role_assignments = optional(%s, null)
+  }))
+}`, interfaces.RoleAssignmentsTypeString),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.RoleAssignments),
+					Message: "nested `role_assignments` interface does not comply with the interface specification: containers.*.role_assignments: expected optional(map(object({...})), {})",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 13, Column: 11},
+					},
+				},
+			},
+		},
+		{
+			Name:      "interface variables are left to their own rule",
+			Interface: interfaces.Lock,
+			Content: `
+variable "private_endpoints" {
+  type = map(object({
+    lock = optional(object({
+      kind = number
+    }), null)
+  }))
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			rule := interfaces.NewVarCheckRuleFromAvmInterface(tc.Interface)
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// PrivateEndpointsUsage checks that the `private_endpoints` variable is consumed by private endpoint resources.
+// It supports both the PrivateEndpoints and the PrivateEndpointsWithSubresourceName interfaces.
+var PrivateEndpointsUsage = &InterfaceUsageRule{
+	RuleName:        "private_endpoints_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#private-endpoints",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "private_endpoints",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkPrivateEndpointsUsage,
+}
+
+// manageDNSZoneGroupVarName is the variable that selects whether the module manages the private DNS zone groups.
+const manageDNSZoneGroupVarName = "private_endpoints_manage_dns_zone_group"
+
+// checkPrivateEndpointsUsage checks the `azurerm_private_endpoint` resources that iterate over `var.private_endpoints`,
+// the split between the managed and unmanaged DNS zone groups, and the per endpoint role assignments and locks.
+func checkPrivateEndpointsUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	var endpoints []*hclsyntax.Block
+	for _, b := range m.resourcesOfType("azurerm_private_endpoint") {
+		if m.attrRefersTo(b, "for_each", "var", "private_endpoints") {
+			endpoints = append(endpoints, b)
+		}
+	}
+	if len(endpoints) == 0 {
+		return r.EmitIssue(rule, "`private_endpoints` variable is declared but no `azurerm_private_endpoint` resource iterates over it with `for_each`", variable.DefRange())
+	}
+
+	withSubresourceName := hasSubresourceName(variable)
+	for _, b := range endpoints {
+		if err := checkPrivateEndpointWiring(rule, r, m, b, withSubresourceName); err != nil {
+			return err
+		}
+	}
+	if err := checkDNSZoneGroupSplit(rule, r, m, variable, endpoints); err != nil {
+		return err
+	}
+
+	checks := []struct {
+		resources []*hclsyntax.Block
+		msg       string
+	}{
+		{
+			resources: append(m.resourcesOfType("azurerm_role_assignment"), m.azapiResourcesOfType("Microsoft.Authorization/roleAssignments")...),
+			msg:       "`private_endpoints` role assignments are not used: no role assignment is scoped to the private endpoints",
+		},
+		{
+			resources: append(m.resourcesOfType("azurerm_management_lock"), m.azapiResourcesOfType("Microsoft.Authorization/locks")...),
+			msg:       "`private_endpoints` locks are not used: no management lock is scoped to the private endpoints",
+		},
+	}
+	for _, c := range checks {
+		if scopedToAny(c.resources, endpoints) {
+			continue
+		}
+		if err := r.EmitIssue(rule, c.msg, variable.DefRange()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPrivateEndpointWiring checks the subresource names and the IP configurations of a private endpoint resource.
+func checkPrivateEndpointWiring(rule tflint.Rule, r tflint.Runner, m *moduleUsage, b *hclsyntax.Block, withSubresourceName bool) error {
+	name := fmt.Sprintf("%s.%s", b.Labels[0], b.Labels[1])
+	if err := m.checkEachValueAttr(rule, r, b, "subnet_id", "subnet_resource_id"); err != nil {
+		return err
+	}
+	if withSubresourceName {
+		wired := false
+		for _, psc := range blocksOfType(b, "private_service_connection") {
+			if m.attrRefersTo(psc, "subresource_names", "each", "value", "subresource_name") {
+				wired = true
+			}
+		}
+		if !wired {
+			msg := fmt.Sprintf("`%s` must set `private_service_connection.subresource_names` from `each.value.subresource_name`", name)
+			if err := r.EmitIssue(rule, msg, b.DefRange()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, attr := range []string{"name", "private_ip_address"} {
+		if m.hasDynamicBlockFrom(b, []string{"ip_configuration"}, attr, "ip_configurations") {
+			continue
+		}
+		msg := fmt.Sprintf("`%s` must set `dynamic \"ip_configuration\"` `content.%s` for each element of `each.value.ip_configurations`", name, attr)
+		if err := r.EmitIssue(rule, msg, b.DefRange()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDNSZoneGroupSplit checks that the private endpoints are split into one resource that manages the
+// DNS zone group and one that ignores it, selected by the `private_endpoints_manage_dns_zone_group` variable.
+func checkDNSZoneGroupSplit(rule tflint.Rule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block, endpoints []*hclsyntax.Block) error {
+	if _, ok := m.variables[manageDNSZoneGroupVarName]; !ok {
+		return r.EmitIssue(rule, fmt.Sprintf("`%s` variable must be declared to select whether the module manages the private DNS zone groups", manageDNSZoneGroupVarName), variable.DefRange())
+	}
+	managed, unmanaged := false, false
+	for _, b := range endpoints {
+		if !m.attrRefersTo(b, "for_each", "var", manageDNSZoneGroupVarName) {
+			continue
+		}
+		for _, zg := range append(dynamicBlocks(b, "private_dns_zone_group"), blocksOfType(b, "private_dns_zone_group")...) {
+			if m.blockRefersTo(zg, "each", "value", "private_dns_zone_resource_ids") {
+				managed = true
+			}
+		}
+		if ignoresChanges(b, "private_dns_zone_group") {
+			unmanaged = true
+		}
+	}
+	if !managed {
+		msg := fmt.Sprintf("no `azurerm_private_endpoint` selected by `var.%s` sets `private_dns_zone_group` from `each.value.private_dns_zone_resource_ids`", manageDNSZoneGroupVarName)
+		if err := r.EmitIssue(rule, msg, variable.DefRange()); err != nil {
+			return err
+		}
+	}
+	if !unmanaged {
+		msg := fmt.Sprintf("no `azurerm_private_endpoint` selected by `var.%s` ignores changes to `private_dns_zone_group`", manageDNSZoneGroupVarName)
+		if err := r.EmitIssue(rule, msg, variable.DefRange()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasSubresourceName returns whether the variable is declared with the PrivateEndpointsWithSubresourceName interface type.
+func hasSubresourceName(variable *hclsyntax.Block) bool {
+	attr, ok := variable.Body.Attributes["type"]
+	if !ok {
+		return false
+	}
+	ty, _, diags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+	if diags.HasErrors() || !ty.IsCollectionType() || !ty.ElementType().IsObjectType() {
+		return false
+	}
+	return ty.ElementType().HasAttribute("subresource_name")
+}
+
+// scopedToAny returns whether the `scope` or `parent_id` of one of the resources refers to one of the targets.
+func scopedToAny(resources, targets []*hclsyntax.Block) bool {
+	for _, b := range resources {
+		for _, attrName := range []string{"scope", "parent_id"} {
+			if attr, ok := b.Body.Attributes[attrName]; ok && refersToResource(attr.Expr, targets) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ignoresChanges returns whether the `lifecycle.ignore_changes` of the resource contains the attribute.
+func ignoresChanges(b *hclsyntax.Block, attrName string) bool {
+	for _, lc := range blocksOfType(b, "lifecycle") {
+		attr, ok := lc.Body.Attributes["ignore_changes"]
+		if !ok {
+			continue
+		}
+		exprs, diags := hcl.ExprList(attr.Expr)
+		if diags.HasErrors() {
+			continue
+		}
+		for _, expr := range exprs {
+			if hcl.ExprAsKeyword(expr) == attrName {
+				return true
+			}
+			if val, diags := expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String && !val.IsNull() && val.AsString() == attrName {
+				return true
+			}
+		}
+	}
+	return false
+}
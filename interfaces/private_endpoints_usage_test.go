@@ -0,0 +1,241 @@
+package interfaces_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const privateEndpointsMain = `resource "azurerm_private_endpoint" "this_managed_dns_zone_groups" {
+  for_each = { for k, v in var.private_endpoints : k => v if var.private_endpoints_manage_dns_zone_group }
+
+  location  = each.value.location
+  name      = each.value.name
+  subnet_id = each.value.subnet_resource_id
+
+  private_service_connection {
+    is_manual_connection           = false
+    name                           = each.value.private_service_connection_name
+    private_connection_resource_id = azurerm_key_vault.this.id
+    subresource_names              = %[1]s
+  }
+  dynamic "ip_configuration" {
+    for_each = each.value.ip_configurations
+
+    content {
+      name               = ip_configuration.value.name
+      private_ip_address = ip_configuration.value.private_ip_address
+    }
+  }
+  dynamic "private_dns_zone_group" {
+    for_each = length(each.value.private_dns_zone_resource_ids) > 0 ? ["this"] : []
+
+    content {
+      name                 = each.value.private_dns_zone_group_name
+      private_dns_zone_ids = each.value.private_dns_zone_resource_ids
+    }
+  }
+}
+
+resource "azurerm_private_endpoint" "this_unmanaged_dns_zone_groups" {
+  for_each = { for k, v in var.private_endpoints : k => v if !var.private_endpoints_manage_dns_zone_group }
+
+  location  = each.value.location
+  name      = each.value.name
+  subnet_id = each.value.subnet_resource_id
+
+  private_service_connection {
+    is_manual_connection           = false
+    name                           = each.value.private_service_connection_name
+    private_connection_resource_id = azurerm_key_vault.this.id
+    subresource_names              = %[1]s
+  }
+  dynamic "ip_configuration" {
+    for_each = each.value.ip_configurations
+
+    content {
+      name               = ip_configuration.value.name
+      private_ip_address = ip_configuration.value.private_ip_address
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [private_dns_zone_group]
+  }
+}
+
+locals {
+  private_endpoint_role_assignments = { for ra in flatten([
+    for pe_k, pe_v in var.private_endpoints : [
+      for rk, rv in pe_v.role_assignments : {
+        private_endpoint_key = pe_k
+        ra_key               = rk
+        role_assignment      = rv
+      }
+    ]
+  ]) : "${ra.private_endpoint_key}-${ra.ra_key}" => ra }
+}
+
+resource "azurerm_role_assignment" "private_endpoint" {
+  for_each = local.private_endpoint_role_assignments
+
+  principal_id         = each.value.role_assignment.principal_id
+  scope                = azurerm_private_endpoint.this_managed_dns_zone_groups[each.value.private_endpoint_key].id
+  role_definition_name = each.value.role_assignment.role_definition_id_or_name
+}
+
+resource "azurerm_management_lock" "private_endpoint" {
+  for_each = { for k, v in var.private_endpoints : k => v if v.lock != null }
+
+  lock_level = each.value.lock.kind
+  name       = coalesce(each.value.lock.name, "lock-${each.key}")
+  scope      = azurerm_private_endpoint.this_managed_dns_zone_groups[each.key].id
+}
+`
+
+const manageDNSZoneGroupVariable = `
+variable "private_endpoints_manage_dns_zone_group" {
+  type     = bool
+  default  = true
+  nullable = false
+}
+`
+
+func TestPrivateEndpointsUsage(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Main     string
+		Variable string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "private endpoints",
+			Main:     fmt.Sprintf(privateEndpointsMain, `["vault"]`),
+			Variable: toTerraformVarType(interfaces.PrivateEndpoints) + manageDNSZoneGroupVariable,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:     "private endpoints with subresource name",
+			Main:     fmt.Sprintf(privateEndpointsMain, `[each.value.subresource_name]`),
+			Variable: toTerraformVarType(interfaces.PrivateEndpointsWithSubresourceName) + manageDNSZoneGroupVariable,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:     "fixed subresource name",
+			Main:     fmt.Sprintf(privateEndpointsMain, `["blob"]`),
+			Variable: toTerraformVarType(interfaces.PrivateEndpointsWithSubresourceName) + manageDNSZoneGroupVariable,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`azurerm_private_endpoint.this_managed_dns_zone_groups` must set `private_service_connection.subresource_names` from `each.value.subresource_name`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 67},
+					},
+				},
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`azurerm_private_endpoint.this_unmanaged_dns_zone_groups` must set `private_service_connection.subresource_names` from `each.value.subresource_name`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 32, Column: 1},
+						End:      hcl.Pos{Line: 32, Column: 69},
+					},
+				},
+			},
+		},
+		{
+			Name: "no dns zone group split",
+			Main: `resource "azurerm_private_endpoint" "this" {
+  for_each = var.private_endpoints
+
+  subnet_id = each.value.subnet_resource_id
+
+  dynamic "ip_configuration" {
+    for_each = each.value.ip_configurations
+
+    content {
+      name               = ip_configuration.value.name
+      private_ip_address = "10.0.0.4"
+    }
+  }
+}`,
+			Variable: toTerraformVarType(interfaces.PrivateEndpoints),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`azurerm_private_endpoint.this` must set `dynamic \"ip_configuration\"` `content.private_ip_address` for each element of `each.value.ip_configurations`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 43},
+					},
+				},
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`private_endpoints_manage_dns_zone_group` variable must be declared to select whether the module manages the private DNS zone groups",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 29},
+					},
+				},
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`private_endpoints` role assignments are not used: no role assignment is scoped to the private endpoints",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 29},
+					},
+				},
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`private_endpoints` locks are not used: no management lock is scoped to the private endpoints",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name:     "not iterated",
+			Main:     `resource "azurerm_private_endpoint" "this" {}`,
+			Variable: toTerraformVarType(interfaces.PrivateEndpoints),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.PrivateEndpointsUsage,
+					Message: "`private_endpoints` variable is declared but no `azurerm_private_endpoint` resource iterates over it with `for_each`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 29},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Main,
+				"variables.tf": tc.Variable,
+			})
+
+			if err := interfaces.PrivateEndpointsUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package interfaces_test
 
 import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
 	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
-	"testing"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestPrivateEndpointsWithSubresourceName(t *testing.T) {
@@ -41,3 +47,43 @@ func TestPrivateEndpointsWithSubresourceName(t *testing.T) {
 		})
 	}
 }
+
+// TestPrivateEndpointsSubresourceVariant checks that the `private_endpoints` rule accepts
+// a variable of the subresource variant, the issues of the other variant are not reported.
+func TestPrivateEndpointsSubresourceVariant(t *testing.T) {
+	var rule tflint.Rule
+	for _, r := range interfaces.NewRules() {
+		if r.Name() == "private_endpoints" {
+			rule = r
+		}
+	}
+	require.NotNil(t, rule)
+
+	runner := helper.TestRunner(t, map[string]string{"variables.tf": toTerraformVarType(interfaces.PrivateEndpointsWithSubresourceName)})
+	require.NoError(t, rule.Check(runner))
+	helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+}
+
+// TestPrivateEndpointsExtension checks that an allowed extension of the `private_endpoints` interface
+// is reported as a warning, not as a failure of both variants.
+func TestPrivateEndpointsExtension(t *testing.T) {
+	var rule tflint.Rule
+	for _, r := range interfaces.NewRules() {
+		if r.Name() == "private_endpoints" {
+			rule = r
+		}
+	}
+	require.NotNil(t, rule)
+	allowed := true
+	cfg := &config.Config{Interfaces: []config.InterfaceConfig{{Name: "private_endpoints", AllowExtensions: &allowed}}}
+	require.NoError(t, rule.(config.Configurable).ApplyConfig(cfg))
+
+	content := strings.Replace(toTerraformVarType(interfaces.PrivateEndpoints), "subnet_resource_id ", "extra = optional(string)\n    subnet_resource_id ", 1)
+	runner := helper.TestRunner(t, map[string]string{"variables.tf": content})
+	require.NoError(t, rule.Check(runner))
+
+	require.Len(t, runner.Issues, 1)
+	assert.Equal(t, "private_endpoints", runner.Issues[0].Rule.Name())
+	assert.Equal(t, tflint.WARNING, runner.Issues[0].Rule.Severity())
+	assert.Contains(t, runner.Issues[0].Message, "extra")
+}
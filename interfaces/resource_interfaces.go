@@ -0,0 +1,151 @@
+package interfaces
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// ResourceInterfaces lists the interfaces supported by an Azure resource type.
+type ResourceInterfaces struct {
+	AzureType                   string   `json:"azure_type"`                    // The Azure resource type, used for `azapi_resource`.
+	TerraformTypes              []string `json:"terraform_types"`               // The azurerm resource types.
+	Interfaces                  []string `json:"interfaces"`                    // The names of the supported interfaces.
+	PrivateEndpointSubresources []string `json:"private_endpoint_subresources"` // The private endpoint subresource names.
+}
+
+//go:embed resource_interfaces.json
+var resourceInterfacesJSON []byte
+
+// ResourceInterfacesTable is the table of the interfaces supported by Azure resource types.
+var ResourceInterfacesTable = func() []ResourceInterfaces {
+	var table []ResourceInterfaces
+	if err := json.Unmarshal(resourceInterfacesJSON, &table); err != nil {
+		panic(err)
+	}
+	return table
+}()
+
+// lookupResourceInterfaces returns the interfaces supported by the resource, or nil if the resource type is not in the table.
+func lookupResourceInterfaces(b *hclsyntax.Block) *ResourceInterfaces {
+	for i, ri := range ResourceInterfacesTable {
+		if slices.Contains(ri.TerraformTypes, b.Labels[0]) {
+			return &ResourceInterfacesTable[i]
+		}
+		if t, ok := azapiType(b); ok && b.Labels[0] == "azapi_resource" && strings.EqualFold(t, ri.AzureType) {
+			return &ResourceInterfacesTable[i]
+		}
+	}
+	return nil
+}
+
+// Check interface compliance with the tflint.Rule and config.Configurable.
+var _ tflint.Rule = new(RequiredInterfacesRule)
+var _ config.Configurable = new(RequiredInterfacesRule)
+var _ config.Profiled = new(RequiredInterfacesRule)
+
+// RequiredInterfacesRule checks that the module declares the variables of all the interfaces
+// supported by its primary resource, according to the ResourceInterfacesTable.
+type RequiredInterfacesRule struct {
+	tflint.DefaultRule
+	Optional map[string]bool // Interfaces that have been opted out in the plugin config.
+}
+
+// NewRequiredInterfacesRule returns a new rule.
+func NewRequiredInterfacesRule() *RequiredInterfacesRule {
+	return &RequiredInterfacesRule{}
+}
+
+// Name returns the rule name.
+func (rir *RequiredInterfacesRule) Name() string {
+	return "required_interfaces"
+}
+
+// Link returns the link to the rule documentation.
+func (rir *RequiredInterfacesRule) Link() string {
+	return "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/"
+}
+
+// Enabled returns whether the rule is enabled.
+func (rir *RequiredInterfacesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the severity of the rule.
+func (rir *RequiredInterfacesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// ModuleTypes returns the module types the rule applies to.
+// Only resource modules have a primary resource.
+func (rir *RequiredInterfacesRule) ModuleTypes() []string {
+	return []string{config.ModuleTypeResource}
+}
+
+// ApplyConfig records the interfaces with `required = false` in the plugin config.
+func (rir *RequiredInterfacesRule) ApplyConfig(c *config.Config) error {
+	rir.Optional = make(map[string]bool)
+	for _, i := range c.Interfaces {
+		if i.Required != nil && !*i.Required {
+			rir.Optional[i.Name] = true
+		}
+	}
+	return nil
+}
+
+// Check checks that the interface variables supported by the primary resources are declared.
+// Only the resources named `this` are considered primary resources.
+func (rir *RequiredInterfacesRule) Check(r tflint.Runner) error {
+	path, err := r.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+	m, err := newModuleUsage(r)
+	if err != nil {
+		return err
+	}
+	for _, b := range m.primaryResources(nil) {
+		ri := lookupResourceInterfaces(b)
+		if ri == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s.%s", b.Labels[0], b.Labels[1])
+		for _, i := range ri.Interfaces {
+			if rir.Optional[i] {
+				continue
+			}
+			variable, ok := m.variables[i]
+			if !ok {
+				msg := fmt.Sprintf("`%s` supports the `%s` interface, but the `%s` variable is not declared", name, i, i)
+				if err := r.EmitIssue(rir, msg, b.DefRange()); err != nil {
+					return err
+				}
+				continue
+			}
+			if i == "private_endpoints" && len(ri.PrivateEndpointSubresources) > 1 && !hasSubresourceName(variable) {
+				msg := fmt.Sprintf("`%s` supports private endpoints on several subresources (%s), the `private_endpoints` variable must declare `subresource_name`", name, strings.Join(ri.PrivateEndpointSubresources, ", "))
+				if err := r.EmitIssue(rir, msg, variable.DefRange()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// IsKnownInterface returns whether the name is the name of one of the built-in Interfaces.
+func IsKnownInterface(name string) bool {
+	return slices.ContainsFunc(Interfaces, func(i AvmInterface) bool {
+		return i.RuleName == name
+	})
+}
@@ -0,0 +1,97 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestRequiredInterfaces(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   *config.Config
+		Expected helper.Issues
+	}{
+		{
+			Name: "all interfaces declared",
+			Content: `resource "azurerm_key_vault" "this" {}
+` + toTerraformVarType(interfaces.DiagnosticSettings) +
+				toTerraformVarType(interfaces.Lock) +
+				toTerraformVarType(interfaces.RoleAssignments) +
+				toTerraformVarType(interfaces.PrivateEndpoints),
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing interfaces",
+			Content: `resource "azapi_resource" "this" {
+  type = "Microsoft.KeyVault/vaults@2023-07-01"
+}
+` + toTerraformVarType(interfaces.DiagnosticSettings) +
+				toTerraformVarType(interfaces.RoleAssignments),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewRequiredInterfacesRule(),
+					Message: "`azapi_resource.this` supports the `lock` interface, but the `lock` variable is not declared",
+				},
+				{
+					Rule:    interfaces.NewRequiredInterfacesRule(),
+					Message: "`azapi_resource.this` supports the `private_endpoints` interface, but the `private_endpoints` variable is not declared",
+				},
+			},
+		},
+		{
+			Name: "opted out",
+			Content: `resource "azurerm_key_vault" "this" {}
+` + toTerraformVarType(interfaces.DiagnosticSettings) +
+				toTerraformVarType(interfaces.Lock) +
+				toTerraformVarType(interfaces.RoleAssignments),
+			Config: &config.Config{
+				Interfaces: []config.InterfaceConfig{{Name: "private_endpoints", Required: new(bool)}},
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "several subresources",
+			Content: `resource "azurerm_storage_account" "this" {}
+` + toTerraformVarType(interfaces.CustomerManagedKey) +
+				toTerraformVarType(interfaces.DiagnosticSettings) +
+				toTerraformVarType(interfaces.Lock) +
+				toTerraformVarType(interfaces.ManagedIdentities) +
+				toTerraformVarType(interfaces.RoleAssignments) +
+				toTerraformVarType(interfaces.PrivateEndpoints),
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewRequiredInterfacesRule(),
+					Message: "`azurerm_storage_account.this` supports private endpoints on several subresources (blob, dfs, file, queue, table, web), the `private_endpoints` variable must declare `subresource_name`",
+				},
+			},
+		},
+		{
+			Name:     "unknown resource",
+			Content:  `resource "azurerm_resource_group" "this" {}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			rule := interfaces.NewRequiredInterfacesRule()
+			if tc.Config != nil {
+				require.NoError(t, rule.ApplyConfig(tc.Config))
+			}
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssuesWithoutRange(t, tc.Expected, runner.Issues)
+		})
+	}
+}
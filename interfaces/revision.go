@@ -0,0 +1,128 @@
+package interfaces
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ config.Configurable = new(InterfaceVarCheckRule)
+
+// revisions returns the current and the previous revisions of the interface, newest first.
+// The returned revisions have no previous revisions themselves.
+func (i AvmInterface) revisions() []AvmInterface {
+	current := i
+	current.PreviousRevisions = nil
+	return append([]AvmInterface{current}, i.PreviousRevisions...)
+}
+
+// revision returns the named revision of the interface.
+func (i AvmInterface) revision(name string) (AvmInterface, bool) {
+	for _, rev := range i.revisions() {
+		if rev.Revision == name {
+			return rev, true
+		}
+	}
+	return AvmInterface{}, false
+}
+
+// revisionAt returns the newest revision of the interface that was effective at the date, as YYYY-MM-DD.
+// The oldest revision is returned if none was effective.
+func (i AvmInterface) revisionAt(date string) AvmInterface {
+	revs := i.revisions()
+	for _, rev := range revs {
+		if rev.EffectiveDate <= date {
+			return rev
+		}
+	}
+	return revs[len(revs)-1]
+}
+
+// ApplyConfig selects the revision of the interface to check against, either by name
+// from the `interface` block, or as the revision effective at the `spec_version` date.
+// The `interface` block may also allow or forbid extensions of the interface type.
+func (vcr *InterfaceVarCheckRule) ApplyConfig(c *config.Config) error {
+	vcr.selectedRevision = ""
+	vcr.specVersion = c.SpecVersion
+	vcr.allowExtensions = vcr.AllowExtensions
+	ic := c.Interface(vcr.RuleName)
+	if ic != nil && ic.AllowExtensions != nil {
+		vcr.allowExtensions = *ic.AllowExtensions
+	}
+	if ic != nil && ic.Revision != "" {
+		if _, ok := vcr.revision(ic.Revision); !ok {
+			return fmt.Errorf("unknown revision %q for interface %s", ic.Revision, vcr.RuleName)
+		}
+		vcr.selectedRevision = ic.Revision
+		return nil
+	}
+	if c.SpecVersion != "" {
+		vcr.selectedRevision = vcr.revisionAt(c.SpecVersion).Revision
+	}
+	return nil
+}
+
+// checkRevisions runs the check against the selected revision of the interface.
+// When the subject, e.g. the variable, does not comply, but matches an older revision that is inside
+// its deprecation window, a single warning is emitted instead of the issues of the selected revision.
+// The deprecation windows are checked at the `spec_version` date, so that pinning it gives stable results,
+// and at today's date otherwise.
+func (vcr *InterfaceVarCheckRule) checkRevisions(r tflint.Runner, subject string, check revisionCheck) error {
+	revs := vcr.revisions()
+	selected := 0
+	for i, rev := range revs {
+		if rev.Revision == vcr.selectedRevision {
+			selected = i
+		}
+	}
+
+	issues, err := common.CheckBuffered(r, vcr.newRevisionRule(revs[selected], check))
+	if err != nil || issues.Empty() {
+		return err
+	}
+	date := vcr.specVersion
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	}
+	for _, prev := range revs[selected+1:] {
+		if prev.DeprecatedUntil == "" || prev.DeprecatedUntil < date {
+			continue
+		}
+		prevIssues, err := common.CheckBuffered(r, vcr.newRevisionRule(prev, check))
+		if err != nil {
+			return err
+		}
+		if prevIssues.Empty() {
+			return r.EmitIssue(common.WithSeverity(vcr, tflint.WARNING),
+				fmt.Sprintf("%s complies with revision %s of the interface specification, which is deprecated and accepted until %s. Update it to revision %s", subject, prev.Revision, prev.DeprecatedUntil, revs[selected].Revision),
+				issues.Range(),
+			)
+		}
+	}
+	return issues.Replay(r, vcr)
+}
+
+// revisionCheck checks the module against the revision of the interface held by the rule.
+type revisionCheck func(rule *InterfaceVarCheckRule, r tflint.Runner) error
+
+// revisionRule runs a check against a single revision of the interface,
+// e.g. of the variable, leaving the nested interfaces to the rule of the interface.
+type revisionRule struct {
+	*InterfaceVarCheckRule
+	check revisionCheck
+}
+
+func (vcr *InterfaceVarCheckRule) newRevisionRule(rev AvmInterface, check revisionCheck) *revisionRule {
+	return &revisionRule{
+		InterfaceVarCheckRule: &InterfaceVarCheckRule{AvmInterface: rev, specVersion: vcr.specVersion, allowExtensions: vcr.allowExtensions},
+		check:                 check,
+	}
+}
+
+// Check runs the check against the revision.
+func (rr *revisionRule) Check(r tflint.Runner) error {
+	return rr.check(rr.InterfaceVarCheckRule, r)
+}
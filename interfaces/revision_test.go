@@ -0,0 +1,132 @@
+package interfaces_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestInterfaceRevisions(t *testing.T) {
+	v1 := toTerraformVarType(interfaces.RoleAssignments.PreviousRevisions[0])
+	v2 := toTerraformVarType(interfaces.RoleAssignments)
+	nestedV1 := fmt.Sprintf(`variable "containers" {
+  type = map(object({
+    role_assignments = optional(%s, {})
+  }))
+}`, interfaces.RoleAssignments.PreviousRevisions[0].VarTypeString)
+
+	cases := []struct {
+		Name             string
+		Interface        interfaces.AvmInterface
+		Config           *config.Config
+		Content          string
+		ExpectedSeverity []tflint.Severity
+		ExpectedMessage  string
+	}{
+		{
+			Name:      "current revision",
+			Interface: interfaces.RoleAssignments,
+			Content:   v2,
+		},
+		{
+			Name:             "previous revision after its deprecation window",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2027-04-02"},
+			Content:          v1,
+			ExpectedSeverity: []tflint.Severity{tflint.ERROR, tflint.ERROR},
+			ExpectedMessage:  "variable type does not comply with the interface specification: role_assignments.*.principal_type: missing attribute, expected optional(string, null)",
+		},
+		{
+			Name:             "previous revision on the last day of its deprecation window",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2027-04-01"},
+			Content:          v1,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessage:  "variable complies with revision v1 of the interface specification, which is deprecated and accepted until 2027-04-01. Update it to revision v2",
+		},
+		{
+			Name:             "previous revision inside its deprecation window",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2024-05-01"},
+			Content:          v1,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessage:  "variable complies with revision v1 of the interface specification, which is deprecated and accepted until 2027-04-01. Update it to revision v2",
+		},
+		{
+			Name:             "nested previous revision inside its deprecation window",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2025-01-01"},
+			Content:          nestedV1,
+			ExpectedSeverity: []tflint.Severity{tflint.WARNING},
+			ExpectedMessage:  "nested `role_assignments` interface `containers.*.role_assignments` complies with revision v1 of the interface specification, which is deprecated and accepted until 2027-04-01. Update it to revision v2",
+		},
+		{
+			Name:             "nested previous revision after its deprecation window",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2027-04-02"},
+			Content:          nestedV1,
+			ExpectedSeverity: []tflint.Severity{tflint.ERROR},
+			ExpectedMessage:  "nested `role_assignments` interface does not comply with the interface specification: containers.*.role_assignments.*.principal_type: missing attribute, expected optional(string, null)",
+		},
+		{
+			Name:      "revision selected by name",
+			Interface: interfaces.RoleAssignments,
+			Config: &config.Config{
+				Interfaces: []config.InterfaceConfig{{Name: "role_assignments", Revision: "v1"}},
+			},
+			Content: v1,
+		},
+		{
+			Name:      "revision selected by spec version",
+			Interface: interfaces.RoleAssignments,
+			Config:    &config.Config{SpecVersion: "2024-01-01"},
+			Content:   v1,
+		},
+		{
+			Name:             "newer revision than selected",
+			Interface:        interfaces.RoleAssignments,
+			Config:           &config.Config{SpecVersion: "2024-01-01"},
+			Content:          v2,
+			ExpectedSeverity: []tflint.Severity{tflint.ERROR},
+			ExpectedMessage:  "variable type does not comply with the interface specification: role_assignments.*.principal_type: unexpected attribute optional(string, null)",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			rule := interfaces.NewVarCheckRuleFromAvmInterface(tc.Interface)
+			if tc.Config != nil {
+				require.NoError(t, rule.ApplyConfig(tc.Config))
+			}
+			require.NoError(t, rule.Check(runner))
+
+			var severities []tflint.Severity
+			for _, issue := range runner.Issues {
+				severities = append(severities, issue.Rule.Severity())
+				assert.Equal(t, "role_assignments", issue.Rule.Name())
+			}
+			assert.Equal(t, tc.ExpectedSeverity, severities)
+			if tc.ExpectedMessage != "" {
+				assert.Equal(t, tc.ExpectedMessage, runner.Issues[0].Message)
+			}
+		})
+	}
+}
+
+func TestInterfaceRevisionUnknown(t *testing.T) {
+	rule := interfaces.NewVarCheckRuleFromAvmInterface(interfaces.RoleAssignments)
+	err := rule.ApplyConfig(&config.Config{
+		Interfaces: []config.InterfaceConfig{{Name: "role_assignments", Revision: "v9"}},
+	})
+	assert.EqualError(t, err, `unknown revision "v9" for interface role_assignments`)
+}
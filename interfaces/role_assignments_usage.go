@@ -0,0 +1,90 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RoleAssignmentsUsage checks that the `role_assignments` variable is consumed by role assignment resources.
+var RoleAssignmentsUsage = &InterfaceUsageRule{
+	RuleName:        "role_assignments_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#role-assignments",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "role_assignments",
+	RuleModuleTypes: []string{config.ModuleTypeResource},
+	CheckUsage:      checkRoleAssignmentsUsage,
+}
+
+// roleAssignmentsAzapiProperties maps the attributes of the interface object to the
+// properties of the `Microsoft.Authorization/roleAssignments` resource.
+// `skip_service_principal_aad_check` is specific to the azurerm provider.
+var roleAssignmentsAzapiProperties = map[string]string{
+	"principal_id":                           "principalId",
+	"description":                            "description",
+	"condition":                              "condition",
+	"condition_version":                      "conditionVersion",
+	"delegated_managed_identity_resource_id": "delegatedManagedIdentityResourceId",
+	"principal_type":                         "principalType",
+}
+
+// checkRoleAssignmentsUsage checks the role assignment resources that iterate over `var.role_assignments`.
+func checkRoleAssignmentsUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	found := false
+	for _, b := range append(m.resourcesOfType("azurerm_role_assignment"), m.azapiResourcesOfType("Microsoft.Authorization/roleAssignments")...) {
+		if !m.attrRefersTo(b, "for_each", "var", "role_assignments") {
+			continue
+		}
+		found = true
+		if err := checkRoleAssignmentWiring(rule, r, m, b); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return r.EmitIssue(rule, "`role_assignments` variable is declared but no `azurerm_role_assignment` or `Microsoft.Authorization/roleAssignments` resource iterates over it with `for_each`", variable.DefRange())
+	}
+	return nil
+}
+
+// checkRoleAssignmentWiring checks that every attribute of the interface object is wired to the role assignment resource.
+// The resource is expected to use `each.value` as the role assignment object.
+func checkRoleAssignmentWiring(rule tflint.Rule, r tflint.Runner, m *moduleUsage, b *hclsyntax.Block) error {
+	name := fmt.Sprintf("%s.%s", b.Labels[0], b.Labels[1])
+	azapi := b.Labels[0] == "azapi_resource"
+
+	var attrNames []string
+	for attr := range roleAssignmentsType.Type.ElementType().AttributeTypes() {
+		if attr != "role_definition_id_or_name" {
+			attrNames = append(attrNames, attr)
+		}
+	}
+	slices.Sort(attrNames)
+
+	for _, attr := range attrNames {
+		var err error
+		if azapi {
+			property, ok := roleAssignmentsAzapiProperties[attr]
+			if !ok {
+				continue
+			}
+			err = m.checkEachValueProperty(rule, r, b, "properties."+property, attr)
+		} else {
+			err = m.checkEachValueAttr(rule, r, b, attr, attr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if azapi {
+		return m.checkEachValueProperty(rule, r, b, "properties.roleDefinitionId", "role_definition_id_or_name")
+	}
+	if m.attrRefersTo(b, "role_definition_id", "each", "value", "role_definition_id_or_name") &&
+		m.attrRefersTo(b, "role_definition_name", "each", "value", "role_definition_id_or_name") {
+		return nil
+	}
+	return r.EmitIssue(rule, fmt.Sprintf("`%s` must branch `each.value.role_definition_id_or_name` into `role_definition_id` and `role_definition_name`", name), b.DefRange())
+}
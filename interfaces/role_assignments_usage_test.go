@@ -0,0 +1,136 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestRoleAssignmentsUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.RoleAssignments)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "azurerm role assignment",
+			Content: `resource "azurerm_role_assignment" "this" {
+  for_each = var.role_assignments
+
+  principal_id                           = each.value.principal_id
+  scope                                  = azurerm_key_vault.this.id
+  condition                              = each.value.condition
+  condition_version                      = each.value.condition_version
+  delegated_managed_identity_resource_id = each.value.delegated_managed_identity_resource_id
+  description                            = each.value.description
+  principal_type                         = each.value.principal_type
+  role_definition_id                     = strcontains(lower(each.value.role_definition_id_or_name), lower(local.role_definition_resource_substring)) ? each.value.role_definition_id_or_name : null
+  role_definition_name                   = strcontains(lower(each.value.role_definition_id_or_name), lower(local.role_definition_resource_substring)) ? null : each.value.role_definition_id_or_name
+  skip_service_principal_aad_check       = each.value.skip_service_principal_aad_check
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "azapi role assignment",
+			Content: `resource "azapi_resource" "role_assignment" {
+  for_each = var.role_assignments
+
+  type      = "Microsoft.Authorization/roleAssignments@2022-04-01"
+  name      = uuid()
+  parent_id = azapi_resource.this.id
+  body = {
+    properties = {
+      principalId                        = each.value.principal_id
+      roleDefinitionId                   = each.value.role_definition_id_or_name
+      condition                          = each.value.condition
+      conditionVersion                   = each.value.condition_version
+      delegatedManagedIdentityResourceId = each.value.delegated_managed_identity_resource_id
+      description                        = each.value.description
+      principalType                      = each.value.principal_type
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "not iterated",
+			Content: `resource "azurerm_role_assignment" "this" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.RoleAssignmentsUsage,
+					Message: "`role_assignments` variable is declared but no `azurerm_role_assignment` or `Microsoft.Authorization/roleAssignments` resource iterates over it with `for_each`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "missing wiring",
+			Content: `resource "azurerm_role_assignment" "this" {
+  for_each = var.role_assignments
+
+  principal_id                           = each.value.principal_id
+  scope                                  = azurerm_key_vault.this.id
+  condition                              = each.value.condition
+  condition_version                      = each.value.condition_version
+  delegated_managed_identity_resource_id = each.value.delegated_managed_identity_resource_id
+  description                            = each.value.description
+  principal_type                         = "ServicePrincipal"
+  role_definition_name                   = each.value.role_definition_id_or_name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.RoleAssignmentsUsage,
+					Message: "`azurerm_role_assignment.this` must set `principal_type` from `each.value.principal_type`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 3},
+						End:      hcl.Pos{Line: 10, Column: 62},
+					},
+				},
+				{
+					Rule:    interfaces.RoleAssignmentsUsage,
+					Message: "`azurerm_role_assignment.this` must set `skip_service_principal_aad_check` from `each.value.skip_service_principal_aad_check`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 42},
+					},
+				},
+				{
+					Rule:    interfaces.RoleAssignmentsUsage,
+					Message: "`azurerm_role_assignment.this` must branch `each.value.role_definition_id_or_name` into `role_definition_id` and `role_definition_name`",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 42},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":      tc.Content,
+				"variables.tf": variable,
+			})
+
+			if err := interfaces.RoleAssignmentsUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package interfaces
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// specFiles holds the specifications of the AVM interfaces, see ParseInterfaceSpecs for the format.
+//
+//go:embed spec/*.hcl
+var specFiles embed.FS
+
+// specInterfaces are the interfaces parsed from specFiles, by specification name.
+var specInterfaces = func() map[string]AvmInterface {
+	is, err := ParseInterfaceSpecs(specFiles)
+	if err != nil {
+		panic(err)
+	}
+	return is
+}()
+
+// specInterface returns the interface with the specification name.
+// The function will panic if there is no such specification.
+func specInterface(name string) AvmInterface {
+	i, ok := specInterfaces[name]
+	if !ok {
+		panic(fmt.Sprintf("interface specification %q not found", name))
+	}
+	return i
+}
+
+var specFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "interface", LabelNames: []string{"name"}},
+	},
+}
+
+var specInterfaceSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "variable"},
+		{Name: "link"},
+		{Name: "severity"},
+		{Name: "enabled"},
+		{Name: "allow_extensions"},
+		{Name: "revision"},
+		{Name: "effective_date"},
+		{Name: "type", Required: true},
+		{Name: "default"},
+		{Name: "nullable"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "validation"},
+		{Type: "previous_revision", LabelNames: []string{"revision"}},
+	},
+}
+
+var specPreviousRevisionSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "effective_date"},
+		{Name: "deprecated_until", Required: true},
+		{Name: "type", Required: true},
+		{Name: "default"},
+		{Name: "nullable"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "validation"},
+	},
+}
+
+var specValidationSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "description", Required: true},
+		{Name: "condition", Required: true},
+		{Name: "accepted"},
+		{Name: "rejected"},
+	},
+}
+
+// rawSpec is an interface block of a specification file, before it is resolved.
+type rawSpec struct {
+	name    string
+	src     []byte
+	content *hcl.BodyContent
+}
+
+// specParser resolves the interface specifications, following the references between them.
+type specParser struct {
+	known     map[string]AvmInterface // Interfaces parsed beforehand, that the specifications may refer to.
+	specs     map[string]*rawSpec
+	names     []string // The specification names, in the order they were added.
+	resolved  map[string]AvmInterface
+	resolving map[string]bool
+}
+
+func newSpecParser(known map[string]AvmInterface) *specParser {
+	return &specParser{
+		known:     known,
+		specs:     make(map[string]*rawSpec),
+		resolved:  make(map[string]AvmInterface),
+		resolving: make(map[string]bool),
+	}
+}
+
+// ParseInterfaceSpecs parses the interface specifications of the `.hcl` files in the file system,
+// and returns the interfaces by specification name. Each file declares one or more interfaces:
+//
+//	interface "lock" {
+//	  variable = "lock"  # The variable name, defaults to the specification name.
+//	  link     = "https://..."
+//	  severity = "error" # One of error, warning or notice, defaults to error.
+//	  enabled  = true    # Defaults to true.
+//	  revision = "v1"    # Defaults to v1, required when there are previous revisions.
+//
+//	  # Whether the variable type may add optional attributes, reported as warnings. Defaults to false.
+//	  allow_extensions = false
+//
+//	  type     = object({ kind = string })
+//	  default  = null    # Omit when the variable must not have a default.
+//	  nullable = true    # Defaults to true, as in Terraform.
+//
+//	  validation {
+//	    description = "..."
+//	    condition   = var.lock == null || var.lock.kind != "None"
+//	    accepted    = [null]
+//	    rejected    = [{ kind = "None" }]
+//	  }
+//
+//	  previous_revision "v0" {
+//	    deprecated_until = "2024-10-01"
+//	    type             = object({ level = string })
+//	  }
+//	}
+//
+// A type refers to the type of another interface as `interface.<name>`, which keeps the
+// interfaces nested in others, e.g. in private endpoints, in sync with their own specification.
+func ParseInterfaceSpecs(fsys fs.FS) (map[string]AvmInterface, error) {
+	p := newSpecParser(nil)
+	err := fs.WalkDir(fsys, ".", func(filename string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(filename) != ".hcl" {
+			return err
+		}
+		src, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return err
+		}
+		return p.addFile(filename, src)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.resolveAll()
+}
+
+// LoadCustomInterfaces parses the custom interface specification files matching the glob patterns,
+// in the format of ParseInterfaceSpecs, and returns the interfaces sorted by variable name.
+// Relative patterns are resolved against dir, not against the working directory of the plugin.
+// Their types may refer to the built-in interfaces, e.g. `optional(interface.lock, null)`.
+// A custom interface must not use the variable of another interface.
+func LoadCustomInterfaces(dir string, patterns []string) ([]AvmInterface, error) {
+	p := newSpecParser(specInterfaces)
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interface file pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no interface file matches %q", pattern)
+		}
+		for _, filename := range matches {
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.addFile(filename, src); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resolved, err := p.resolveAll()
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]AvmInterface, 0, len(resolved))
+	for _, name := range p.names {
+		i := resolved[name]
+		if IsKnownInterface(i.RuleName) || slices.ContainsFunc(custom, func(c AvmInterface) bool { return c.RuleName == i.RuleName }) {
+			return nil, fmt.Errorf("custom interface %q: the %s variable is already checked by another interface", name, i.RuleName)
+		}
+		custom = append(custom, i)
+	}
+	slices.SortFunc(custom, func(a, b AvmInterface) int {
+		return strings.Compare(a.RuleName, b.RuleName)
+	})
+	return custom, nil
+}
+
+// addFile adds the interface specifications declared in the file.
+func (p *specParser) addFile(filename string, src []byte) error {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+	content, diags := file.Body.Content(specFileSchema)
+	if diags.HasErrors() {
+		return diags
+	}
+	for _, b := range content.Blocks {
+		name := b.Labels[0]
+		_, isKnown := p.known[name]
+		if _, ok := p.specs[name]; ok || isKnown {
+			return fmt.Errorf("%s: duplicate interface specification %q", b.DefRange, name)
+		}
+		c, diags := b.Body.Content(specInterfaceSchema)
+		if diags.HasErrors() {
+			return diags
+		}
+		p.specs[name] = &rawSpec{name: name, src: src, content: c}
+		p.names = append(p.names, name)
+	}
+	return nil
+}
+
+// resolveAll resolves the added specifications and returns their interfaces by specification name.
+func (p *specParser) resolveAll() (map[string]AvmInterface, error) {
+	for _, name := range p.names {
+		if _, err := p.resolve(name); err != nil {
+			return nil, err
+		}
+	}
+	return p.resolved, nil
+}
+
+// resolve returns the interface of the specification, resolving the interfaces its type refers to first.
+func (p *specParser) resolve(name string) (AvmInterface, error) {
+	if i, ok := p.resolved[name]; ok {
+		return i, nil
+	}
+	if i, ok := p.known[name]; ok {
+		return i, nil
+	}
+	s, ok := p.specs[name]
+	if !ok {
+		return AvmInterface{}, fmt.Errorf("unknown interface specification %q", name)
+	}
+	if p.resolving[name] {
+		return AvmInterface{}, fmt.Errorf("interface specification %q refers to itself", name)
+	}
+	p.resolving[name] = true
+	defer delete(p.resolving, name)
+
+	attrs := s.content.Attributes
+	var i AvmInterface
+	var err error
+	if i.RuleName, err = specString(attrs, "variable", name); err != nil {
+		return AvmInterface{}, err
+	}
+	if i.RuleLink, err = specString(attrs, "link", ""); err != nil {
+		return AvmInterface{}, err
+	}
+	if i.RuleEnabled, err = specBool(attrs, "enabled", true); err != nil {
+		return AvmInterface{}, err
+	}
+	if i.AllowExtensions, err = specBool(attrs, "allow_extensions", false); err != nil {
+		return AvmInterface{}, err
+	}
+	if i.Revision, err = specString(attrs, "revision", "v1"); err != nil {
+		return AvmInterface{}, err
+	}
+	if _, ok := attrs["revision"]; !ok && slices.ContainsFunc(s.content.Blocks, func(b *hcl.Block) bool { return b.Type == "previous_revision" }) {
+		return AvmInterface{}, fmt.Errorf("interface specification %q has previous revisions, it must set its revision", name)
+	}
+	severity, err := specString(attrs, "severity", "error")
+	if err != nil {
+		return AvmInterface{}, err
+	}
+	switch severity {
+	case "error":
+		i.RuleSeverity = tflint.ERROR
+	case "warning":
+		i.RuleSeverity = tflint.WARNING
+	case "notice":
+		i.RuleSeverity = tflint.NOTICE
+	default:
+		return AvmInterface{}, fmt.Errorf("%s: severity must be one of error, warning or notice", attrs["severity"].Expr.Range())
+	}
+	if err := p.resolveRevision(s, s.content, &i); err != nil {
+		return AvmInterface{}, err
+	}
+
+	for _, b := range s.content.Blocks {
+		if b.Type != "previous_revision" {
+			continue
+		}
+		c, diags := b.Body.Content(specPreviousRevisionSchema)
+		if diags.HasErrors() {
+			return AvmInterface{}, diags
+		}
+		prev := AvmInterface{
+			RuleName:     i.RuleName,
+			RuleLink:     i.RuleLink,
+			RuleEnabled:  i.RuleEnabled,
+			RuleSeverity: i.RuleSeverity,
+			Revision:     b.Labels[0],
+			// The extensions are allowed alike for every revision.
+			AllowExtensions: i.AllowExtensions,
+		}
+		if prev.DeprecatedUntil, err = specString(c.Attributes, "deprecated_until", ""); err != nil {
+			return AvmInterface{}, err
+		}
+		if err := p.resolveRevision(s, c, &prev); err != nil {
+			return AvmInterface{}, err
+		}
+		i.PreviousRevisions = append(i.PreviousRevisions, prev)
+	}
+
+	p.resolved[name] = i
+	return i, nil
+}
+
+// resolveRevision sets the type, default, nullable, effective date and validations of a revision of the interface.
+func (p *specParser) resolveRevision(s *rawSpec, c *hcl.BodyContent, i *AvmInterface) error {
+	var err error
+	if i.EffectiveDate, err = specString(c.Attributes, "effective_date", ""); err != nil {
+		return err
+	}
+	if i.VarTypeString, err = p.typeString(s, c.Attributes["type"].Expr); err != nil {
+		return err
+	}
+	ty, diags := varcheck.NewTypeConstraintWithDefaultsFromBytes([]byte(i.VarTypeString))
+	if diags.HasErrors() {
+		return fmt.Errorf("%s: invalid type for interface %s: %s", c.Attributes["type"].Expr.Range(), s.name, diags.Error())
+	}
+	// Without a default attribute the variable must not declare one, which is denoted by an unknown value.
+	def := cty.UnknownVal(ty.Type)
+	if attr, ok := c.Attributes["default"]; ok {
+		if def, diags = attr.Expr.Value(nil); diags.HasErrors() {
+			return diags
+		}
+	}
+	nullable, err := specBool(c.Attributes, "nullable", true)
+	if err != nil {
+		return err
+	}
+	i.VarCheck = varcheck.NewVarCheck(ty, def, nullable)
+
+	for _, b := range c.Blocks {
+		if b.Type != "validation" {
+			continue
+		}
+		v, err := specValidation(s, b)
+		if err != nil {
+			return err
+		}
+		i.Validations = append(i.Validations, v)
+	}
+	return nil
+}
+
+// typeString returns the source of the type expression, formatted,
+// with the `interface.<name>` references replaced by the type of the interface.
+func (p *specParser) typeString(s *rawSpec, expr hcl.Expression) (string, error) {
+	rng := expr.Range()
+	var refs []hcl.Traversal
+	for _, t := range expr.Variables() {
+		// The object attribute names are traversals too, only the references have two steps.
+		if t.RootName() == "interface" && len(t) > 1 {
+			refs = append(refs, t)
+		}
+	}
+	// Replace from the end so that the offsets of the earlier references stay valid.
+	slices.SortFunc(refs, func(a, b hcl.Traversal) int {
+		return b.SourceRange().Start.Byte - a.SourceRange().Start.Byte
+	})
+	src := slices.Clone(rng.SliceBytes(s.src))
+	for _, t := range refs {
+		attr, ok := t[1].(hcl.TraverseAttr)
+		if !ok || len(t) != 2 {
+			return "", fmt.Errorf("%s: invalid interface reference, expected interface.<name>", t.SourceRange())
+		}
+		ref, err := p.resolve(attr.Name)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", t.SourceRange(), err)
+		}
+		tr := t.SourceRange()
+		src = slices.Replace(src, tr.Start.Byte-rng.Start.Byte, tr.End.Byte-rng.Start.Byte, []byte(ref.VarTypeString)...)
+	}
+	formatted := hclwrite.Format(append([]byte("type = "), src...))
+	return strings.TrimSpace(strings.TrimPrefix(string(formatted), "type =")), nil
+}
+
+// specValidation returns the interface validation of a validation block.
+// The condition and the sample values are kept as source text.
+func specValidation(s *rawSpec, b *hcl.Block) (InterfaceValidation, error) {
+	c, diags := b.Body.Content(specValidationSchema)
+	if diags.HasErrors() {
+		return InterfaceValidation{}, diags
+	}
+	var v InterfaceValidation
+	var err error
+	if v.Description, err = specString(c.Attributes, "description", ""); err != nil {
+		return InterfaceValidation{}, err
+	}
+	v.Condition = string(c.Attributes["condition"].Expr.Range().SliceBytes(s.src))
+	if v.Accepted, err = specSamples(s, c.Attributes["accepted"]); err != nil {
+		return InterfaceValidation{}, err
+	}
+	if v.Rejected, err = specSamples(s, c.Attributes["rejected"]); err != nil {
+		return InterfaceValidation{}, err
+	}
+	return v, nil
+}
+
+// specSamples returns the source text of the elements of a list of sample values.
+func specSamples(s *rawSpec, attr *hcl.Attribute) ([]string, error) {
+	if attr == nil {
+		return nil, nil
+	}
+	exprs, diags := hcl.ExprList(attr.Expr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	samples := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		samples = append(samples, string(e.Range().SliceBytes(s.src)))
+	}
+	return samples, nil
+}
+
+// specString returns the value of the string attribute, or def if it is not set.
+func specString(attrs hcl.Attributes, name, def string) (string, error) {
+	val, err := specValue(attrs, name, cty.String)
+	if err != nil || val.IsNull() {
+		return def, err
+	}
+	return val.AsString(), nil
+}
+
+// specBool returns the value of the bool attribute, or def if it is not set.
+func specBool(attrs hcl.Attributes, name string, def bool) (bool, error) {
+	val, err := specValue(attrs, name, cty.Bool)
+	if err != nil || val.IsNull() {
+		return def, err
+	}
+	return val.True(), nil
+}
+
+// specValue returns the value of the attribute converted to the type, or a null value if it is not set.
+func specValue(attrs hcl.Attributes, name string, ty cty.Type) (cty.Value, error) {
+	attr, ok := attrs[name]
+	if !ok {
+		return cty.NullVal(ty), nil
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, diags
+	}
+	val, err := convert.Convert(val, ty)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("%s: invalid value for %s: %s", attr.Expr.Range(), name, err)
+	}
+	if !val.IsKnown() {
+		return cty.NilVal, fmt.Errorf("%s: %s must be a constant", attr.Expr.Range(), name)
+	}
+	return val, nil
+}
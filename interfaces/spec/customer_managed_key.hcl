@@ -0,0 +1,15 @@
+interface "customer_managed_key" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/shared/interfaces/#customer-managed-keys"
+  revision = "v1"
+
+  type = object({
+    key_vault_resource_id = string
+    key_name              = string
+    key_version           = optional(string, null)
+    user_assigned_identity = optional(object({
+      resource_id = string
+    }), null)
+  })
+  default  = null
+  nullable = true
+}
@@ -0,0 +1,46 @@
+interface "diagnostic_settings" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#diagnostic-settings"
+  revision = "v1"
+
+  type = map(object({
+    name                                     = optional(string, null)
+    log_categories                           = optional(set(string), [])
+    log_groups                               = optional(set(string), ["allLogs"])
+    metric_categories                        = optional(set(string), ["AllMetrics"])
+    log_analytics_destination_type           = optional(string, "Dedicated")
+    workspace_resource_id                    = optional(string, null)
+    storage_account_resource_id              = optional(string, null)
+    event_hub_authorization_rule_resource_id = optional(string, null)
+    event_hub_name                           = optional(string, null)
+    marketplace_partner_resource_id          = optional(string, null)
+  }))
+  default  = {}
+  nullable = false
+
+  validation {
+    description = "log_analytics_destination_type must be one of Dedicated or AzureDiagnostics"
+    condition   = alltrue([for _, v in var.diagnostic_settings : contains(["Dedicated", "AzureDiagnostics"], v.log_analytics_destination_type)])
+    accepted = [
+      {},
+      { ds = { workspace_resource_id = "/id" } },
+      { ds = { workspace_resource_id = "/id", log_analytics_destination_type = "AzureDiagnostics" } },
+    ]
+    rejected = [
+      { ds = { workspace_resource_id = "/id", log_analytics_destination_type = "Shared" } },
+    ]
+  }
+
+  validation {
+    description = "at least one of workspace_resource_id, storage_account_resource_id, event_hub_authorization_rule_resource_id or marketplace_partner_resource_id must be set"
+    condition   = alltrue([for _, v in var.diagnostic_settings : v.workspace_resource_id != null || v.storage_account_resource_id != null || v.event_hub_authorization_rule_resource_id != null || v.marketplace_partner_resource_id != null])
+    accepted = [
+      { ds = { storage_account_resource_id = "/id" } },
+      { ds = { event_hub_authorization_rule_resource_id = "/id", event_hub_name = "hub" } },
+      { ds = { marketplace_partner_resource_id = "/id" } },
+    ]
+    rejected = [
+      { ds = {} },
+      { ds = { event_hub_name = "hub" } },
+    ]
+  }
+}
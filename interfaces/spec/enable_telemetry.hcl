@@ -0,0 +1,8 @@
+interface "enable_telemetry" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-sfr3---category-telemetry---deploymentusage-telemetry"
+  revision = "v1"
+
+  type     = bool
+  default  = true
+  nullable = false
+}
@@ -0,0 +1,7 @@
+# The location interface has no default, the variable must not set one.
+interface "location" {
+  revision = "v1"
+
+  type     = string
+  nullable = false
+}
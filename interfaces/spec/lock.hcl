@@ -0,0 +1,25 @@
+interface "lock" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#resource-locks"
+  revision = "v1"
+
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default  = null
+  nullable = true
+
+  validation {
+    description = "lock.kind must be one of CanNotDelete or ReadOnly"
+    condition   = var.lock != null ? contains(["CanNotDelete", "ReadOnly"], var.lock.kind) : true
+    accepted = [
+      null,
+      { kind = "CanNotDelete" },
+      { kind = "ReadOnly", name = "lock" },
+    ]
+    rejected = [
+      { kind = "None" },
+      { kind = "Delete" },
+    ]
+  }
+}
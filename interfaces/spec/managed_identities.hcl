@@ -0,0 +1,11 @@
+interface "managed_identities" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#managed-identities"
+  revision = "v1"
+
+  type = object({
+    system_assigned            = optional(bool, false)
+    user_assigned_resource_ids = optional(set(string), [])
+  })
+  default  = {}
+  nullable = false
+}
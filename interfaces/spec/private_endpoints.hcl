@@ -0,0 +1,56 @@
+# The private endpoints interfaces nest the role assignments, lock and tags interfaces.
+
+interface "private_endpoints" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#private-endpoints"
+  revision = "v1"
+
+  type = map(object({
+    name                                    = optional(string, null)
+    role_assignments                        = optional(interface.role_assignments, {})
+    lock                                    = optional(interface.lock, null)
+    tags                                    = optional(interface.tags, null)
+    subnet_resource_id                      = string
+    private_dns_zone_group_name             = optional(string, "default")
+    private_dns_zone_resource_ids           = optional(set(string), [])
+    application_security_group_associations = optional(map(string), {})
+    private_service_connection_name         = optional(string, null)
+    network_interface_name                  = optional(string, null)
+    location                                = optional(string, null)
+    resource_group_name                     = optional(string, null)
+    ip_configurations = optional(map(object({
+      name               = string
+      private_ip_address = string
+    })), {})
+  }))
+  default  = {}
+  nullable = false
+}
+
+# The variant for resources with several private endpoint subresources.
+interface "private_endpoints_with_subresource_name" {
+  variable = "private_endpoints"
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#private-endpoints"
+  revision = "v1"
+
+  type = map(object({
+    name                                    = optional(string, null)
+    role_assignments                        = optional(interface.role_assignments, {})
+    lock                                    = optional(interface.lock, null)
+    tags                                    = optional(interface.tags, null)
+    subnet_resource_id                      = string
+    subresource_name                        = string
+    private_dns_zone_group_name             = optional(string, "default")
+    private_dns_zone_resource_ids           = optional(set(string), [])
+    application_security_group_associations = optional(map(string), {})
+    private_service_connection_name         = optional(string, null)
+    network_interface_name                  = optional(string, null)
+    location                                = optional(string, null)
+    resource_group_name                     = optional(string, null)
+    ip_configurations = optional(map(object({
+      name               = string
+      private_ip_address = string
+    })), {})
+  }))
+  default  = {}
+  nullable = false
+}
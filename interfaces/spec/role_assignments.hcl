@@ -0,0 +1,48 @@
+interface "role_assignments" {
+  link           = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#role-assignments"
+  revision       = "v2"
+  effective_date = "2024-04-01"
+
+  type = map(object({
+    role_definition_id_or_name             = string
+    principal_id                           = string
+    description                            = optional(string, null)
+    skip_service_principal_aad_check       = optional(bool, false)
+    condition                              = optional(string, null)
+    condition_version                      = optional(string, null)
+    delegated_managed_identity_resource_id = optional(string, null)
+    principal_type                         = optional(string, null)
+  }))
+  default  = {}
+  nullable = false
+
+  validation {
+    description = "principal_type must be null or one of User, Group, ServicePrincipal, ForeignGroup or Device"
+    condition   = alltrue([for _, v in var.role_assignments : v.principal_type == null ? true : contains(["User", "Group", "ServicePrincipal", "ForeignGroup", "Device"], v.principal_type)])
+    accepted = [
+      {},
+      { ra = { role_definition_id_or_name = "Reader", principal_id = "00000000-0000-0000-0000-000000000000" } },
+      { ra = { role_definition_id_or_name = "Reader", principal_id = "00000000-0000-0000-0000-000000000000", principal_type = "ServicePrincipal" } },
+    ]
+    rejected = [
+      { ra = { role_definition_id_or_name = "Reader", principal_id = "00000000-0000-0000-0000-000000000000", principal_type = "Robot" } },
+    ]
+  }
+
+  # Revision v1, before `principal_type` was added.
+  previous_revision "v1" {
+    deprecated_until = "2027-04-01"
+
+    type = map(object({
+      role_definition_id_or_name             = string
+      principal_id                           = string
+      description                            = optional(string, null)
+      skip_service_principal_aad_check       = optional(bool, false)
+      condition                              = optional(string, null)
+      condition_version                      = optional(string, null)
+      delegated_managed_identity_resource_id = optional(string, null)
+    }))
+    default  = {}
+    nullable = false
+  }
+}
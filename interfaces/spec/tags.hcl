@@ -0,0 +1,8 @@
+interface "tags" {
+  link     = "https://azure.github.io/Azure-Verified-Modules/specs/tf/interfaces/#tags"
+  revision = "v1"
+
+  type     = map(string)
+  default  = null
+  nullable = true
+}
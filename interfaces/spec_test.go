@@ -0,0 +1,179 @@
+package interfaces_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TestInterfaceSpecComposition checks that the interfaces nested in the private endpoints interfaces
+// have the same type as their own specification.
+func TestInterfaceSpecComposition(t *testing.T) {
+	for _, pe := range []interfaces.AvmInterface{interfaces.PrivateEndpoints, interfaces.PrivateEndpointsWithSubresourceName} {
+		attrs := pe.TypeConstraintWithDefs.Type.ElementType().AttributeTypes()
+		for _, nested := range []interfaces.AvmInterface{interfaces.Lock, interfaces.RoleAssignments, interfaces.Tags} {
+			assert.Truef(t, attrs[nested.RuleName].Equals(nested.TypeConstraintWithDefs.Type), "%s.%s", pe.RuleName, nested.RuleName)
+		}
+	}
+}
+
+func TestParseInterfaceSpecs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base.hcl": {Data: []byte(`
+interface "base" {
+  type = object({ kind = string })
+}
+`)},
+		"nested/composed.hcl": {Data: []byte(`
+interface "composed_v2" {
+  variable = "composed"
+  severity = "warning"
+  revision = "v2"
+
+  allow_extensions = true
+
+  type = map(object({
+    base = optional(interface.base, null)
+  }))
+  default  = {}
+  nullable = false
+
+  validation {
+    description = "keys must be short"
+    condition   = alltrue([for k, _ in var.composed : length(k) < 4])
+    accepted    = [{}, { abc = {} }]
+    rejected    = [{ abcd = {} }]
+  }
+
+  previous_revision "v1" {
+    deprecated_until = "2024-10-01"
+    type             = map(interface.base)
+  }
+}
+`)},
+	}
+	is, err := interfaces.ParseInterfaceSpecs(fsys)
+	require.NoError(t, err)
+	require.Len(t, is, 2)
+
+	base := is["base"]
+	assert.Equal(t, "base", base.RuleName)
+	assert.Equal(t, tflint.ERROR, base.RuleSeverity)
+	assert.True(t, base.RuleEnabled)
+	assert.True(t, base.Nullable)
+	assert.False(t, base.Default.IsKnown(), "no default attribute means no default")
+	assert.False(t, base.AllowExtensions)
+	assert.Equal(t, "v1", base.Revision, "the revision defaults to v1")
+
+	composed := is["composed_v2"]
+	assert.Equal(t, "composed", composed.RuleName)
+	assert.Equal(t, tflint.WARNING, composed.RuleSeverity)
+	assert.True(t, composed.Default.RawEquals(cty.EmptyObjectVal))
+	assert.False(t, composed.Nullable)
+	assert.True(t, composed.AllowExtensions)
+	assert.Equal(t, "map(object({\n  base = optional(object({ kind = string }), null)\n}))", composed.VarTypeString)
+	require.Len(t, composed.Validations, 1)
+	assert.Equal(t, "alltrue([for k, _ in var.composed : length(k) < 4])", composed.Validations[0].Condition)
+	assert.Equal(t, []string{"{}", "{ abc = {} }"}, composed.Validations[0].Accepted)
+	assert.Equal(t, []string{"{ abcd = {} }"}, composed.Validations[0].Rejected)
+
+	require.Len(t, composed.PreviousRevisions, 1)
+	prev := composed.PreviousRevisions[0]
+	assert.Equal(t, "v1", prev.Revision)
+	assert.Equal(t, "2024-10-01", prev.DeprecatedUntil)
+	assert.Equal(t, "composed", prev.RuleName)
+	assert.True(t, prev.AllowExtensions)
+	assert.Equal(t, "map(object({ kind = string }))", prev.VarTypeString)
+}
+
+func TestParseInterfaceSpecsErrors(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name: "unknown reference",
+			Content: `
+interface "a" {
+  revision = "v1"
+  type     = list(interface.b)
+}`,
+			Expected: `unknown interface specification "b"`,
+		},
+		{
+			Name: "cycle",
+			Content: `
+interface "a" {
+  revision = "v1"
+  type     = list(interface.b)
+}
+interface "b" {
+  revision = "v1"
+  type     = list(interface.a)
+}`,
+			Expected: `interface specification "a" refers to itself`,
+		},
+		{
+			Name: "duplicate",
+			Content: `
+interface "a" {
+  revision = "v1"
+  type     = string
+}
+interface "a" {
+  revision = "v1"
+  type     = string
+}`,
+			Expected: `duplicate interface specification "a"`,
+		},
+		{
+			Name: "invalid severity",
+			Content: `
+interface "a" {
+  revision = "v1"
+  severity = "fatal"
+  type     = string
+}`,
+			Expected: "severity must be one of error, warning or notice",
+		},
+		{
+			Name: "invalid type",
+			Content: `
+interface "a" {
+  revision = "v1"
+  type     = strin
+}`,
+			Expected: "invalid type for interface a",
+		},
+		{
+			Name: "missing revision with previous revisions",
+			Content: `
+interface "a" {
+  type = string
+
+  previous_revision "v1" {
+    deprecated_until = "2024-10-01"
+    type             = number
+  }
+}`,
+			Expected: `interface specification "a" has previous revisions, it must set its revision`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			_, err := interfaces.ParseInterfaceSpecs(fstest.MapFS{"spec.hcl": {Data: []byte(tc.Content)}})
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), tc.Expected), err.Error())
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package interfaces
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// telemetryFile is the file that holds the telemetry resources of a module.
+const telemetryFile = "main.telemetry.tf"
+
+// TelemetryUsage checks that the `enable_telemetry` variable controls the canonical telemetry resources.
+var TelemetryUsage = &InterfaceUsageRule{
+	RuleName:        "telemetry_usage",
+	RuleLink:        "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-sfr3---category-telemetry---deploymentusage-telemetry",
+	RuleSeverity:    tflint.ERROR,
+	VarName:         "enable_telemetry",
+	RequireVariable: true,
+	CheckUsage:      checkTelemetryUsage,
+}
+
+// telemetryBlock is a block that must be declared in the telemetry file.
+type telemetryBlock struct {
+	blockType string // `data` or `resource`.
+	typeName  string
+}
+
+func (tb telemetryBlock) String() string {
+	if tb.blockType == "data" {
+		return fmt.Sprintf("data %q", tb.typeName)
+	}
+	return fmt.Sprintf("resource %q", tb.typeName)
+}
+
+var telemetryBlocks = []telemetryBlock{
+	{blockType: "data", typeName: "modtm_module_source"},
+	{blockType: "resource", typeName: "random_uuid"},
+	{blockType: "resource", typeName: "modtm_telemetry"},
+}
+
+// checkTelemetryUsage checks that `main.telemetry.tf` declares the `modtm_module_source` data source,
+// and the `random_uuid` and `modtm_telemetry` resources, each with `count = var.enable_telemetry ? 1 : 0`.
+// The tags sent by `modtm_telemetry` must include the module source and version from the data source,
+// or the version from `local.module_version`.
+func checkTelemetryUsage(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error {
+	files, err := r.GetFiles()
+	if err != nil {
+		return err
+	}
+	found := false
+	for name := range files {
+		// The file names are relative to the working directory, not to the module.
+		if filepath.Base(name) == telemetryFile {
+			found = true
+		}
+	}
+	if !found {
+		return r.EmitIssue(rule, fmt.Sprintf("`enable_telemetry` variable is declared but `%s` does not exist", telemetryFile), variable.DefRange())
+	}
+
+	var source, telemetry *hclsyntax.Block
+	for _, tb := range telemetryBlocks {
+		b := m.telemetryBlock(tb)
+		if b == nil {
+			if err := r.EmitIssue(rule, fmt.Sprintf("`%s` must declare a `%s` block", telemetryFile, tb), variable.DefRange()); err != nil {
+				return err
+			}
+			continue
+		}
+		if !isTelemetryCount(b) {
+			if err := r.EmitIssue(rule,
+				fmt.Sprintf("`%s.%s` must set `count = var.enable_telemetry ? 1 : 0`", tb.typeName, b.Labels[1]),
+				attrRange(b, "count"),
+			); err != nil {
+				return err
+			}
+		}
+		switch tb.typeName {
+		case "modtm_module_source":
+			source = b
+		case "modtm_telemetry":
+			telemetry = b
+		}
+	}
+	if telemetry == nil {
+		return nil
+	}
+
+	attr, ok := telemetry.Body.Attributes["tags"]
+	for _, key := range []string{"module_source", "module_version"} {
+		if ok && source != nil && hasTagFrom(attr.Expr, key, "data", source.Labels[0], source.Labels[1]) {
+			continue
+		}
+		// Modules that carry `locals.version.tf.json` may report their version from the local instead.
+		if ok && key == "module_version" && hasTagFrom(attr.Expr, key, "local") {
+			continue
+		}
+		from := "the `modtm_module_source` data source"
+		if key == "module_version" {
+			from += " or `local.module_version`"
+		}
+		if err := r.EmitIssue(rule,
+			fmt.Sprintf("`modtm_telemetry.%s` must set the `%s` tag from %s", telemetry.Labels[1], key, from),
+			attrRange(telemetry, "tags"),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// telemetryBlock returns the first block of the given kind declared in the telemetry file, or nil if there is none.
+func (m *moduleUsage) telemetryBlock(tb telemetryBlock) *hclsyntax.Block {
+	var blocks []*hclsyntax.Block
+	if tb.blockType == "data" {
+		for _, b := range m.data {
+			blocks = append(blocks, b)
+		}
+	} else {
+		blocks = m.resources
+	}
+	var found *hclsyntax.Block
+	for _, b := range blocks {
+		if b.Labels[0] != tb.typeName || filepath.Base(b.Range().Filename) != telemetryFile {
+			continue
+		}
+		// Data sources are kept in a map, pick the first one in the file.
+		if found == nil || b.Range().Start.Byte < found.Range().Start.Byte {
+			found = b
+		}
+	}
+	return found
+}
+
+// isTelemetryCount returns whether the block sets `count = var.enable_telemetry ? 1 : 0`.
+func isTelemetryCount(b *hclsyntax.Block) bool {
+	attr, ok := b.Body.Attributes["count"]
+	if !ok {
+		return false
+	}
+	cond, ok := attr.Expr.(*hclsyntax.ConditionalExpr)
+	if !ok {
+		return false
+	}
+	traversal, diags := hcl.AbsTraversalForExpr(cond.Condition)
+	if diags.HasErrors() || len(traversal) != 2 || !traversalHasPrefix(traversal, []string{"var", "enable_telemetry"}) {
+		return false
+	}
+	return isNumberLiteral(cond.TrueResult, 1) && isNumberLiteral(cond.FalseResult, 0)
+}
+
+func isNumberLiteral(expr hcl.Expression, n int64) bool {
+	lit, ok := expr.(*hclsyntax.LiteralValueExpr)
+	if !ok || lit.Val.Type() != cty.Number {
+		return false
+	}
+	return lit.Val.Equals(cty.NumberIntVal(n)).True()
+}
+
+// hasTagFrom returns whether an object constructor in the expression, e.g. an argument of `merge()`,
+// sets the key from the attribute of the same name of a value that refers to the given path,
+// e.g. `module_source = one(data.modtm_module_source.telemetry).module_source`.
+func hasTagFrom(expr hclsyntax.Expression, key string, path ...string) bool {
+	found := false
+	_ = hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		obj, ok := node.(*hclsyntax.ObjectConsExpr)
+		if !ok || found {
+			return nil
+		}
+		for _, item := range obj.Items {
+			if hcl.ExprAsKeyword(item.KeyExpr) != key && !isStringLiteral(item.KeyExpr, key) {
+				continue
+			}
+			if readsAttrFrom(item.ValueExpr, key, path) {
+				found = true
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// readsAttrFrom returns whether the expression reads the attribute from a value that refers to the path,
+// either directly, e.g. `data.modtm_module_source.telemetry[0].module_source`,
+// or from the result of a function call, e.g. `one(data.modtm_module_source.telemetry).module_source`.
+func readsAttrFrom(expr hclsyntax.Expression, attr string, path []string) bool {
+	found := false
+	_ = hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch e := node.(type) {
+		case *hclsyntax.ScopeTraversalExpr:
+			if len(e.Traversal) > len(path) && traversalHasPrefix(e.Traversal, path) && lastStepName(e.Traversal) == attr {
+				found = true
+			}
+		case *hclsyntax.RelativeTraversalExpr:
+			if lastStepName(e.Traversal) != attr {
+				return nil
+			}
+			for _, traversal := range e.Source.Variables() {
+				if traversalHasPrefix(traversal, path) {
+					found = true
+				}
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// lastStepName returns the name of the last step of the traversal, or an empty string if it has none.
+func lastStepName(traversal hcl.Traversal) string {
+	if len(traversal) == 0 {
+		return ""
+	}
+	name, _ := traversalStepName(traversal[len(traversal)-1])
+	return name
+}
+
+func isStringLiteral(expr hcl.Expression, s string) bool {
+	val, diags := expr.Value(nil)
+	return !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() && val.AsString() == s
+}
@@ -0,0 +1,233 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestTelemetryUsage(t *testing.T) {
+	variable := toTerraformVarType(interfaces.EnableTelemetry)
+	canonical := `data "azapi_client_config" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+}
+
+data "modtm_module_source" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  module_path = path.module
+}
+
+resource "random_uuid" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+}
+
+resource "modtm_telemetry" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  tags = merge({
+    subscription_id = one(data.azapi_client_config.telemetry).subscription_id
+    tenant_id       = one(data.azapi_client_config.telemetry).tenant_id
+    module_source   = one(data.modtm_module_source.telemetry).module_source
+    module_version  = one(data.modtm_module_source.telemetry).module_version
+    random_id       = one(random_uuid.telemetry).result
+  }, { location = var.location })
+}`
+
+	cases := []struct {
+		Name       string
+		Files      map[string]string
+		NoVariable bool
+		Expected   helper.Issues
+	}{
+		{
+			Name: "canonical telemetry",
+			Files: map[string]string{
+				"main.telemetry.tf": canonical,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "module version from the local",
+			Files: map[string]string{
+				"main.telemetry.tf": `data "modtm_module_source" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  module_path = path.module
+}
+
+resource "random_uuid" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+}
+
+resource "modtm_telemetry" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  tags = {
+    module_source  = one(data.modtm_module_source.telemetry).module_source
+    module_version = local.module_version
+    random_id      = one(random_uuid.telemetry).result
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "tag from another attribute of the data source",
+			Files: map[string]string{
+				"main.telemetry.tf": `data "modtm_module_source" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  module_path = path.module
+}
+
+resource "random_uuid" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+}
+
+resource "modtm_telemetry" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  tags = {
+    module_source  = one(data.modtm_module_source.telemetry).module_version
+    module_version = data.modtm_module_source.telemetry[0].module_version
+    random_id      = one(random_uuid.telemetry).result
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`modtm_telemetry.telemetry` must set the `module_source` tag from the `modtm_module_source` data source",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 14, Column: 3},
+						End:      hcl.Pos{Line: 18, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name:  "no telemetry file",
+			Files: map[string]string{"main.tf": `resource "azurerm_key_vault" "this" {}`},
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`enable_telemetry` variable is declared but `main.telemetry.tf` does not exist",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name:     "telemetry file of a module in a subdirectory",
+			Files:    map[string]string{"terraform-azurerm-avm-res-keyvault-vault/main.telemetry.tf": canonical},
+			Expected: helper.Issues{},
+		},
+		{
+			Name:       "no enable_telemetry variable",
+			Files:      map[string]string{"main.telemetry.tf": canonical},
+			NoVariable: true,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`enable_telemetry` variable is not declared",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			Name: "telemetry resources not wired",
+			Files: map[string]string{
+				"main.telemetry.tf": `data "modtm_module_source" "telemetry" {
+  module_path = path.module
+}
+
+resource "modtm_telemetry" "telemetry" {
+  count = var.enable_telemetry ? 0 : 1
+
+  tags = {
+    module_source = "registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm"
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`modtm_module_source.telemetry` must set `count = var.enable_telemetry ? 1 : 0`",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 39},
+					},
+				},
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`main.telemetry.tf` must declare a `resource \"random_uuid\"` block",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 28},
+					},
+				},
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`modtm_telemetry.telemetry` must set `count = var.enable_telemetry ? 1 : 0`",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 39},
+					},
+				},
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`modtm_telemetry.telemetry` must set the `module_source` tag from the `modtm_module_source` data source",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 10, Column: 4},
+					},
+				},
+				{
+					Rule:    interfaces.TelemetryUsage,
+					Message: "`modtm_telemetry.telemetry` must set the `module_version` tag from the `modtm_module_source` data source or `local.module_version`",
+					Range: hcl.Range{
+						Filename: "main.telemetry.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 10, Column: 4},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			files := map[string]string{}
+			if !tc.NoVariable {
+				files["variables.tf"] = variable
+			}
+			for name, content := range tc.Files {
+				files[name] = content
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := interfaces.TelemetryUsage.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// collectionElementStep is the path step used for the element of a map, list or set.
+const collectionElementStep = "*"
+
+// TypeDifference is a single structural difference between a declared
+// type constraint and the type constraint of an interface.
+type TypeDifference struct {
+	Path     []string // Path to the attribute, e.g. ["role_assignments", "*", "principal_type"].
+	Expected string   // The expected attribute type, empty if the attribute is not expected.
+	Got      string   // The declared attribute type, empty if the attribute is not declared.
+	// Extension is whether the attribute is not expected but optional,
+	// so that the declared type is a compatible extension of the wanted one.
+	Extension bool
+}
+
+// String returns the difference in the form `path: expected X, got Y`.
+func (d TypeDifference) String() string {
+	path := strings.Join(d.Path, ".")
+	switch {
+	case d.Got == "":
+		return fmt.Sprintf("%s: missing attribute, expected %s", path, d.Expected)
+	case d.Expected == "":
+		return fmt.Sprintf("%s: unexpected attribute %s", path, d.Got)
+	default:
+		return fmt.Sprintf("%s: expected %s, got %s", path, d.Expected, d.Got)
+	}
+}
+
+// DiffTypeConstraints returns the structural differences between the declared type constraint
+// and the wanted one. The paths of the differences are rooted at the given name.
+// Differences are returned in a stable order, with object attributes sorted by name.
+func DiffTypeConstraints(name string, got, want varcheck.TypeConstraintWithDefaults) []TypeDifference {
+	return diffTypes([]string{name}, got.Type, want.Type, got.Default, want.Default)
+}
+
+func diffTypes(path []string, got, want cty.Type, gotDefs, wantDefs *typeexpr.Defaults) []TypeDifference {
+	switch {
+	case got.IsObjectType() && want.IsObjectType():
+		return diffObjects(path, got, want, gotDefs, wantDefs)
+	case sameCollectionKind(got, want):
+		return diffTypes(
+			append(slices.Clone(path), collectionElementStep),
+			got.ElementType(), want.ElementType(),
+			childDefaults(gotDefs, ""), childDefaults(wantDefs, ""),
+		)
+	case !got.Equals(want):
+		return []TypeDifference{{Path: path, Expected: typeName(want), Got: typeName(got)}}
+	}
+	return nil
+}
+
+func diffObjects(path []string, got, want cty.Type, gotDefs, wantDefs *typeexpr.Defaults) []TypeDifference {
+	names := make([]string, 0, len(want.AttributeTypes()))
+	for n := range want.AttributeTypes() {
+		names = append(names, n)
+	}
+	for n := range got.AttributeTypes() {
+		if !want.HasAttribute(n) {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+
+	var diffs []TypeDifference
+	for _, n := range names {
+		attrPath := append(slices.Clone(path), n)
+		if !got.HasAttribute(n) {
+			diffs = append(diffs, TypeDifference{Path: attrPath, Expected: attrTypeName(want, wantDefs, n)})
+			continue
+		}
+		if !want.HasAttribute(n) {
+			diffs = append(diffs, TypeDifference{Path: attrPath, Got: attrTypeName(got, gotDefs, n), Extension: got.AttributeOptional(n)})
+			continue
+		}
+		gotAttr, wantAttr := got.AttributeType(n), want.AttributeType(n)
+		structural := isStructural(gotAttr) && isStructural(wantAttr)
+		sameModifier := got.AttributeOptional(n) == want.AttributeOptional(n) &&
+			sameDefault(defaultValue(gotDefs, n), defaultValue(wantDefs, n))
+		// Primitive attributes are reported as a whole, structural ones are
+		// reported for their modifier and then walked into.
+		if !sameModifier || (!structural && !gotAttr.Equals(wantAttr)) {
+			diffs = append(diffs, TypeDifference{
+				Path:     attrPath,
+				Expected: attrTypeName(want, wantDefs, n),
+				Got:      attrTypeName(got, gotDefs, n),
+			})
+		}
+		if structural {
+			diffs = append(diffs, diffTypes(attrPath, gotAttr, wantAttr, childDefaults(gotDefs, n), childDefaults(wantDefs, n))...)
+		}
+	}
+	return diffs
+}
+
+func sameCollectionKind(got, want cty.Type) bool {
+	return (got.IsMapType() && want.IsMapType()) ||
+		(got.IsListType() && want.IsListType()) ||
+		(got.IsSetType() && want.IsSetType())
+}
+
+func isStructural(t cty.Type) bool {
+	return t.IsObjectType() || t.IsCollectionType()
+}
+
+func childDefaults(d *typeexpr.Defaults, key string) *typeexpr.Defaults {
+	if d == nil {
+		return nil
+	}
+	return d.Children[key]
+}
+
+func defaultValue(d *typeexpr.Defaults, attr string) *cty.Value {
+	if d == nil {
+		return nil
+	}
+	v, ok := d.DefaultValues[attr]
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func sameDefault(got, want *cty.Value) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return got.RawEquals(*want)
+}
+
+// attrTypeName renders the type of an object attribute, including the optional() modifier.
+func attrTypeName(obj cty.Type, defs *typeexpr.Defaults, attr string) string {
+	name := typeName(obj.AttributeType(attr))
+	if !obj.AttributeOptional(attr) {
+		return name
+	}
+	if def := defaultValue(defs, attr); def != nil {
+		return fmt.Sprintf("optional(%s, %s)", name, valueString(*def))
+	}
+	return fmt.Sprintf("optional(%s)", name)
+}
+
+// typeName renders a type in a short form, objects are abbreviated as `object({...})`.
+func typeName(t cty.Type) string {
+	switch {
+	case t.IsObjectType():
+		return "object({...})"
+	case t.IsMapType():
+		return fmt.Sprintf("map(%s)", typeName(t.ElementType()))
+	case t.IsListType():
+		return fmt.Sprintf("list(%s)", typeName(t.ElementType()))
+	case t.IsSetType():
+		return fmt.Sprintf("set(%s)", typeName(t.ElementType()))
+	case t.Equals(cty.DynamicPseudoType):
+		return "any"
+	}
+	return t.FriendlyNameForConstraint()
+}
+
+// valueString renders a value as HCL on a single line, e.g. `{ kind = "ReadOnly" }`.
+func valueString(v cty.Value) string {
+	if v.IsNull() || !v.IsKnown() {
+		return strings.TrimSpace(string(hclwrite.TokensForValue(v).Bytes()))
+	}
+	ty := v.Type()
+	switch {
+	case ty.IsObjectType() || ty.IsMapType():
+		if v.LengthInt() == 0 {
+			return "{}"
+		}
+		items := make([]string, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			key := k.AsString()
+			if !hclsyntax.ValidIdentifier(key) {
+				key = valueString(k)
+			}
+			items = append(items, fmt.Sprintf("%s = %s", key, valueString(ev)))
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(items, ", "))
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		items := make([]string, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			items = append(items, valueString(ev))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+	}
+	return strings.TrimSpace(string(hclwrite.TokensForValue(v).Bytes()))
+}
+
+// typeExprRange returns the range of the part of a type expression that declares the given path,
+// with the first element of the path being the variable name.
+// If the path cannot be followed to the end, the range of the deepest part found is returned.
+func typeExprRange(expr hcl.Expression, path []string) hcl.Range {
+	rng := expr.Range()
+	for _, step := range path[1:] {
+		call, diags := hcl.ExprCall(expr)
+		if diags.HasErrors() || len(call.Arguments) == 0 {
+			return rng
+		}
+		if call.Name == "optional" {
+			call, diags = hcl.ExprCall(call.Arguments[0])
+			if diags.HasErrors() || len(call.Arguments) == 0 {
+				return rng
+			}
+		}
+		switch {
+		case step == collectionElementStep && slices.Contains([]string{"map", "list", "set"}, call.Name):
+			expr = call.Arguments[0]
+			rng = expr.Range()
+		case call.Name == "object":
+			pairs, diags := hcl.ExprMap(call.Arguments[0])
+			if diags.HasErrors() {
+				return rng
+			}
+			idx := slices.IndexFunc(pairs, func(p hcl.KeyValuePair) bool {
+				return hcl.ExprAsKeyword(p.Key) == step
+			})
+			if idx < 0 {
+				return rng
+			}
+			expr = pairs[idx].Value
+			rng = hcl.RangeBetween(pairs[idx].Key.Range(), expr.Range())
+		default:
+			return rng
+		}
+	}
+	return rng
+}
@@ -0,0 +1,85 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTypeConstraints(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Got      string
+		Want     string
+		Expected []string
+	}{
+		{
+			Name:     "equal",
+			Got:      interfaces.RoleAssignmentsTypeString,
+			Want:     interfaces.RoleAssignmentsTypeString,
+			Expected: nil,
+		},
+		{
+			Name: "missing optional modifier",
+			Got: `map(object({
+  role_definition_id_or_name             = string
+  principal_id                           = string
+  description                            = optional(string, null)
+  skip_service_principal_aad_check       = optional(bool, false)
+  condition                              = optional(string, null)
+  condition_version                      = optional(string, null)
+  delegated_managed_identity_resource_id = optional(string, null)
+  principal_type                         = string
+}))`,
+			Want: interfaces.RoleAssignmentsTypeString,
+			Expected: []string{
+				"role_assignments.*.principal_type: expected optional(string, null), got string",
+			},
+		},
+		{
+			Name: "missing and extra attributes",
+			Got: `object({
+  kind  = string
+  notes = optional(string)
+})`,
+			Want: interfaces.LockTypeString,
+			Expected: []string{
+				"role_assignments.name: missing attribute, expected optional(string, null)",
+				"role_assignments.notes: unexpected attribute optional(string)",
+			},
+		},
+		{
+			Name:     "wrong collection kind",
+			Got:      `list(object({ kind = string }))`,
+			Want:     `map(object({ kind = string }))`,
+			Expected: []string{"role_assignments: expected map(object({...})), got list(object({...}))"},
+		},
+		{
+			Name:     "wrong nested collection default",
+			Got:      `object({ ids = optional(set(string), ["a"]) })`,
+			Want:     `object({ ids = optional(set(string), []) })`,
+			Expected: []string{`role_assignments.ids: expected optional(set(string), []), got optional(set(string), ["a"])`},
+		},
+		{
+			Name:     "wrong primitive type",
+			Got:      `map(number)`,
+			Want:     `map(string)`,
+			Expected: []string{"role_assignments.*: expected string, got number"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			got := interfaces.StringToTypeConstraintWithDefaults(tc.Got)
+			want := interfaces.StringToTypeConstraintWithDefaults(tc.Want)
+			var actual []string
+			for _, d := range interfaces.DiffTypeConstraints("role_assignments", got, want) {
+				actual = append(actual, d.String())
+			}
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
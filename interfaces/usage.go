@@ -0,0 +1,427 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Check interface compliance with the tflint.Rule and config.Profiled.
+var (
+	_ tflint.Rule     = new(InterfaceUsageRule)
+	_ config.Profiled = new(InterfaceUsageRule)
+)
+
+// InterfaceUsageRule is the struct that represents a rule that checks
+// that an interface variable is consumed by the resources of the module.
+// The rule only runs when the module declares the interface variable.
+type InterfaceUsageRule struct {
+	tflint.DefaultRule
+	RuleName     string          // RuleName of the rule.
+	RuleLink     string          // RuleLink to the interface specification.
+	RuleSeverity tflint.Severity // Severity of the rule.
+	VarName      string          // Name of the interface variable that must be consumed.
+	// RequireVariable reports the modules that do not declare the variable, the rule passes on them otherwise.
+	RequireVariable bool
+	// RuleModuleTypes are the module types the rule applies to, all module types when empty.
+	RuleModuleTypes []string
+	// CheckUsage checks the module content and emits issues for the variable block.
+	CheckUsage func(rule *InterfaceUsageRule, r tflint.Runner, m *moduleUsage, variable *hclsyntax.Block) error
+}
+
+// NewInterfaceUsageRule returns a new instance of the usage rule, so that the rules of each rule set are separate.
+func NewInterfaceUsageRule(template *InterfaceUsageRule) *InterfaceUsageRule {
+	rule := *template
+	return &rule
+}
+
+// Name returns the rule name.
+func (ur *InterfaceUsageRule) Name() string {
+	return ur.RuleName
+}
+
+// Link returns the link to the rule documentation.
+func (ur *InterfaceUsageRule) Link() string {
+	return ur.RuleLink
+}
+
+// Enabled returns whether the rule is enabled.
+func (ur *InterfaceUsageRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the severity of the rule.
+func (ur *InterfaceUsageRule) Severity() tflint.Severity {
+	return ur.RuleSeverity
+}
+
+// ModuleTypes returns the module types the rule applies to.
+func (ur *InterfaceUsageRule) ModuleTypes() []string {
+	if len(ur.RuleModuleTypes) == 0 {
+		return []string{config.ModuleTypeResource, config.ModuleTypePattern, config.ModuleTypeUtility}
+	}
+	return ur.RuleModuleTypes
+}
+
+// Check checks whether the interface variable, if declared, is consumed by the module.
+// A missing variable is only reported when the rule requires it.
+func (ur *InterfaceUsageRule) Check(r tflint.Runner) error {
+	path, err := r.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+	m, err := newModuleUsage(r)
+	if err != nil {
+		return err
+	}
+	variable, ok := m.variables[ur.VarName]
+	if !ok {
+		if !ur.RequireVariable {
+			return nil
+		}
+		return r.EmitIssue(ur, fmt.Sprintf("`%s` variable is not declared", ur.VarName), m.start)
+	}
+	return ur.CheckUsage(ur, r, m, variable)
+}
+
+// moduleUsage is the parsed content of the root module, used by the rules that check
+// how an interface variable is consumed by the resources of the module.
+// Only HCL native syntax files are inspected.
+type moduleUsage struct {
+	start     hcl.Range // Start of the first file, for the issues about the module as a whole.
+	variables map[string]*hclsyntax.Block
+	locals    map[string]*hclsyntax.Attribute
+	data      map[string]*hclsyntax.Block // Data sources by `<type>.<name>`.
+	resources []*hclsyntax.Block
+}
+
+// newModuleUsage collects the variables, locals, data sources and resources of the root module.
+// Files are visited in name order so that the issues are emitted in a stable order.
+func newModuleUsage(r tflint.Runner) (*moduleUsage, error) {
+	files, err := r.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	m := &moduleUsage{
+		variables: make(map[string]*hclsyntax.Block),
+		locals:    make(map[string]*hclsyntax.Attribute),
+		data:      make(map[string]*hclsyntax.Block),
+	}
+	if len(names) > 0 {
+		m.start = hcl.Range{Filename: names[0], Start: hcl.InitialPos, End: hcl.InitialPos}
+	}
+	for _, name := range names {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, b := range body.Blocks {
+			switch {
+			case b.Type == "variable" && len(b.Labels) == 1:
+				m.variables[b.Labels[0]] = b
+			case b.Type == "locals":
+				for n, attr := range b.Body.Attributes {
+					m.locals[n] = attr
+				}
+			case b.Type == "data" && len(b.Labels) == 2:
+				m.data[b.Labels[0]+"."+b.Labels[1]] = b
+			case b.Type == "resource" && len(b.Labels) == 2:
+				m.resources = append(m.resources, b)
+			}
+		}
+	}
+	return m, nil
+}
+
+// resourcesOfType returns the resources with one of the given types.
+func (m *moduleUsage) resourcesOfType(types ...string) []*hclsyntax.Block {
+	var res []*hclsyntax.Block
+	for _, b := range m.resources {
+		if slices.Contains(types, b.Labels[0]) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// azapiResourcesOfType returns the `azapi_resource` resources whose `type` attribute
+// is the given Azure resource type, regardless of the API version.
+func (m *moduleUsage) azapiResourcesOfType(azureType string) []*hclsyntax.Block {
+	var res []*hclsyntax.Block
+	for _, b := range m.resourcesOfType("azapi_resource") {
+		if t, ok := azapiType(b); ok && strings.EqualFold(t, azureType) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// azapiType returns the Azure resource type of an `azapi_resource`, without the API version.
+func azapiType(b *hclsyntax.Block) (string, bool) {
+	attr, ok := b.Body.Attributes["type"]
+	if !ok {
+		return "", false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+		return "", false
+	}
+	t, _, _ := strings.Cut(val.AsString(), "@")
+	return t, true
+}
+
+// primaryResources returns the primary resources of the module, which by convention are named `this`.
+// The given resources, e.g. the extension resources being checked, are excluded.
+func (m *moduleUsage) primaryResources(exclude []*hclsyntax.Block) []*hclsyntax.Block {
+	var res []*hclsyntax.Block
+	for _, b := range m.resources {
+		if b.Labels[1] == "this" && !slices.Contains(exclude, b) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// attrRefersToPrimaryResource returns whether the named attribute of the block refers to a primary resource.
+// When the module has no resource named `this`, a reference to any managed resource is accepted.
+func (m *moduleUsage) attrRefersToPrimaryResource(b *hclsyntax.Block, attrName string, exclude []*hclsyntax.Block) bool {
+	attr, ok := b.Body.Attributes[attrName]
+	if !ok {
+		return false
+	}
+	if primary := m.primaryResources(exclude); len(primary) > 0 {
+		return refersToResource(attr.Expr, primary)
+	}
+	for _, traversal := range attr.Expr.Variables() {
+		if isManagedResourceReference(traversal) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersTo returns whether the expression refers to the given path, e.g. `var.lock.kind`,
+// or to a part of it. References to locals and data sources are followed.
+func (m *moduleUsage) refersTo(expr hcl.Expression, path ...string) bool {
+	return m.refersToVisited(expr, path, make(map[string]bool))
+}
+
+func (m *moduleUsage) refersToVisited(expr hcl.Expression, path []string, visited map[string]bool) bool {
+	if expr == nil {
+		return false
+	}
+	for _, traversal := range expr.Variables() {
+		if traversalHasPrefix(traversal, path) {
+			return true
+		}
+		switch traversal.RootName() {
+		case "local":
+			if len(traversal) < 2 {
+				continue
+			}
+			name, ok := traversalStepName(traversal[1])
+			if !ok || visited["local."+name] {
+				continue
+			}
+			visited["local."+name] = true
+			if local, ok := m.locals[name]; ok && m.refersToVisited(local.Expr, path, visited) {
+				return true
+			}
+		case "data":
+			if len(traversal) < 3 {
+				continue
+			}
+			dataType, _ := traversalStepName(traversal[1])
+			dataName, _ := traversalStepName(traversal[2])
+			key := dataType + "." + dataName
+			if visited["data."+key] {
+				continue
+			}
+			visited["data."+key] = true
+			if b, ok := m.data[key]; ok && m.blockRefersToVisited(b, path, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// blockRefersTo returns whether any attribute in the block, or its nested blocks, refers to the path.
+func (m *moduleUsage) blockRefersTo(b *hclsyntax.Block, path ...string) bool {
+	return m.blockRefersToVisited(b, path, make(map[string]bool))
+}
+
+func (m *moduleUsage) blockRefersToVisited(b *hclsyntax.Block, path []string, visited map[string]bool) bool {
+	for _, attr := range b.Body.Attributes {
+		if m.refersToVisited(attr.Expr, path, visited) {
+			return true
+		}
+	}
+	for _, nested := range b.Body.Blocks {
+		if m.blockRefersToVisited(nested, path, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// attrRefersTo returns whether the named attribute of the block refers to the path.
+func (m *moduleUsage) attrRefersTo(b *hclsyntax.Block, attrName string, path ...string) bool {
+	attr, ok := b.Body.Attributes[attrName]
+	return ok && m.refersTo(attr.Expr, path...)
+}
+
+// repetitionRefersTo returns whether the `count` or `for_each` of the block refers to the path.
+func (m *moduleUsage) repetitionRefersTo(b *hclsyntax.Block, path ...string) bool {
+	return m.attrRefersTo(b, "count", path...) || m.attrRefersTo(b, "for_each", path...)
+}
+
+// blocksOfType returns the blocks of the given type nested directly in the block.
+func blocksOfType(b *hclsyntax.Block, blockType string) []*hclsyntax.Block {
+	var res []*hclsyntax.Block
+	for _, nested := range b.Body.Blocks {
+		if nested.Type == blockType {
+			res = append(res, nested)
+		}
+	}
+	return res
+}
+
+// dynamicBlocks returns the `dynamic` blocks of the given type nested directly in the block.
+func dynamicBlocks(b *hclsyntax.Block, blockType string) []*hclsyntax.Block {
+	var res []*hclsyntax.Block
+	for _, nested := range b.Body.Blocks {
+		if nested.Type == "dynamic" && len(nested.Labels) == 1 && nested.Labels[0] == blockType {
+			res = append(res, nested)
+		}
+	}
+	return res
+}
+
+// hasDynamicBlockFrom returns whether the block has a dynamic block of one of the given types that iterates
+// over `each.value.<field>` and sets the attribute of its content from the iterator value.
+func (m *moduleUsage) hasDynamicBlockFrom(b *hclsyntax.Block, blockTypes []string, attr, field string) bool {
+	for _, blockType := range blockTypes {
+		for _, d := range dynamicBlocks(b, blockType) {
+			content := dynamicContent(d)
+			if content == nil || !m.attrRefersTo(d, "for_each", "each", "value", field) {
+				continue
+			}
+			if m.attrRefersTo(content, attr, dynamicIterator(d), "value") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dynamicIterator returns the name of the iterator of a dynamic block.
+func dynamicIterator(b *hclsyntax.Block) string {
+	if attr, ok := b.Body.Attributes["iterator"]; ok {
+		if kw := hcl.ExprAsKeyword(attr.Expr); kw != "" {
+			return kw
+		}
+	}
+	return b.Labels[0]
+}
+
+// dynamicContent returns the `content` block of a dynamic block, or nil if there is none.
+func dynamicContent(b *hclsyntax.Block) *hclsyntax.Block {
+	for _, nested := range b.Body.Blocks {
+		if nested.Type == "content" {
+			return nested
+		}
+	}
+	return nil
+}
+
+// isManagedResourceReference returns whether the traversal refers to a managed resource, e.g. `azurerm_key_vault.this.id`.
+func isManagedResourceReference(traversal hcl.Traversal) bool {
+	switch traversal.RootName() {
+	case "var", "local", "data", "module", "each", "count", "self", "path", "terraform":
+		return false
+	}
+	return len(traversal) >= 2
+}
+
+// refersToResource returns whether the expression refers to one of the given resources.
+func refersToResource(expr hcl.Expression, resources []*hclsyntax.Block) bool {
+	for _, traversal := range expr.Variables() {
+		if !isManagedResourceReference(traversal) {
+			continue
+		}
+		name, _ := traversalStepName(traversal[1])
+		for _, res := range resources {
+			if res.Labels[0] == traversal.RootName() && res.Labels[1] == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func traversalHasPrefix(traversal hcl.Traversal, path []string) bool {
+	if len(traversal) < len(path) || traversal.RootName() != path[0] {
+		return false
+	}
+	for i, step := range path[1:] {
+		name, ok := traversalStepName(traversal[i+1])
+		if !ok || name != step {
+			return false
+		}
+	}
+	return true
+}
+
+func traversalStepName(step hcl.Traverser) (string, bool) {
+	switch s := step.(type) {
+	case hcl.TraverseAttr:
+		return s.Name, true
+	case hcl.TraverseIndex:
+		if s.Key.Type() == cty.String && !s.Key.IsNull() && s.Key.IsKnown() {
+			return s.Key.AsString(), true
+		}
+	}
+	return "", false
+}
+
+// attrRange returns the range of the named attribute of the block, or the block definition range if it is not set.
+func attrRange(b *hclsyntax.Block, attrName string) hcl.Range {
+	if attr, ok := b.Body.Attributes[attrName]; ok {
+		return attr.SrcRange
+	}
+	return b.DefRange()
+}
+
+// checkEachValueAttr emits an issue unless the named attribute of the resource refers to `each.value.<field>`.
+func (m *moduleUsage) checkEachValueAttr(rule tflint.Rule, r tflint.Runner, b *hclsyntax.Block, attrName, field string) error {
+	if m.attrRefersTo(b, attrName, "each", "value", field) {
+		return nil
+	}
+	return r.EmitIssue(rule, fmt.Sprintf("`%s.%s` must set `%s` from `each.value.%s`", b.Labels[0], b.Labels[1], attrName, field), attrRange(b, attrName))
+}
+
+// checkEachValueProperty emits an issue unless the resource refers to `each.value.<field>` anywhere in its body.
+// It is used for `azapi_resource` resources, where the properties are nested in the `body` object.
+func (m *moduleUsage) checkEachValueProperty(rule tflint.Rule, r tflint.Runner, b *hclsyntax.Block, property, field string) error {
+	if m.blockRefersTo(b, "each", "value", field) {
+		return nil
+	}
+	return r.EmitIssue(rule, fmt.Sprintf("`%s.%s` must set `%s` from `each.value.%s`", b.Labels[0], b.Labels[1], property, field), attrRange(b, "body"))
+}
@@ -0,0 +1,127 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// InterfaceValidation is a validation that the variable of an interface must declare.
+// Rather than comparing the text of the condition, the declared `validation` blocks
+// are evaluated against sample values: together they must accept every value in Accepted
+// and reject every value in Rejected.
+type InterfaceValidation struct {
+	Description string   // Description of what is validated, used in issue messages.
+	Condition   string   // The condition from the interface specification, for reference.
+	Accepted    []string // HCL expressions of values that must be accepted.
+	Rejected    []string // HCL expressions of values that must be rejected.
+}
+
+// checkValidations checks that the validation blocks of the variable implement the interface validations.
+// It is designed to be supplied to the Checker.Check() function.
+func checkValidations(vcr *InterfaceVarCheckRule, r tflint.Runner, b *hclext.Block) func() (bool, error) {
+	return func() (bool, error) {
+		var conditions []*hclext.Attribute
+		for _, vb := range b.Body.Blocks {
+			if vb.Type != "validation" {
+				continue
+			}
+			if cond, ok := vb.Body.Attributes["condition"]; ok {
+				conditions = append(conditions, cond)
+			}
+		}
+		for _, v := range vcr.Validations {
+			if err := checkValidation(vcr, r, b, v, conditions); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// checkValidation emits at most one issue for the interface validation,
+// for the first sample value that is not handled as the specification requires.
+func checkValidation(vcr *InterfaceVarCheckRule, r tflint.Runner, b *hclext.Block, v InterfaceValidation, conditions []*hclext.Attribute) error {
+	for _, sample := range v.Accepted {
+		val, err := vcr.sampleValue(sample)
+		if err != nil {
+			return err
+		}
+		for _, cond := range conditions {
+			if conditionResult(cond.Expr, vcr.RuleName, val).RawEquals(cty.False) {
+				return r.EmitIssue(vcr,
+					fmt.Sprintf("variable validation does not comply with the interface specification: %s, but `%s` is rejected", v.Description, sample),
+					cond.Range,
+				)
+			}
+		}
+	}
+	for _, sample := range v.Rejected {
+		val, err := vcr.sampleValue(sample)
+		if err != nil {
+			return err
+		}
+		rejected := false
+		for _, cond := range conditions {
+			if !conditionResult(cond.Expr, vcr.RuleName, val).RawEquals(cty.True) {
+				rejected = true
+				break
+			}
+		}
+		if !rejected {
+			return r.EmitIssue(vcr,
+				fmt.Sprintf("variable validation does not comply with the interface specification: %s, but `%s` is accepted. Expected a condition equivalent to `%s`", v.Description, sample, v.Condition),
+				b.DefRange,
+			)
+		}
+	}
+	return nil
+}
+
+// conditionResult evaluates the condition with the value assigned to `var.<name>`.
+// It returns cty.True or cty.False, or an unknown value if the result cannot be worked out here:
+// the condition depends on anything else, calls a function that is not available,
+// or fails to evaluate. Unknown results neither accept nor reject the value.
+func conditionResult(expr hcl.Expression, name string, val cty.Value) cty.Value {
+	res, diags := expr.Value(newEvalContext(map[string]cty.Value{name: val}))
+	if diags.HasErrors() {
+		return cty.UnknownVal(cty.Bool)
+	}
+	res, err := convert.Convert(res, cty.Bool)
+	if err != nil || res.IsNull() || !res.IsKnown() {
+		return cty.UnknownVal(cty.Bool)
+	}
+	return res
+}
+
+// sampleValue parses a sample value and converts it to the interface type,
+// applying the optional attribute defaults the same way Terraform does for input variables.
+func (i AvmInterface) sampleValue(sample string) (cty.Value, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(sample), "sample", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("invalid sample value for interface %s: %s", i.RuleName, diags.Error())
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("invalid sample value for interface %s: %s", i.RuleName, diags.Error())
+	}
+	val, err := i.typedValue(val)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("invalid sample value for interface %s: %s", i.RuleName, err)
+	}
+	return val, nil
+}
+
+// typedValue converts a value to the interface type,
+// applying the optional attribute defaults the same way Terraform does for input variables.
+func (i AvmInterface) typedValue(val cty.Value) (cty.Value, error) {
+	if defs := i.TypeConstraintWithDefs.Default; defs != nil {
+		val = defs.Apply(val)
+	}
+	return convert.Convert(val, i.TypeConstraintWithDefs.Type)
+}
@@ -0,0 +1,171 @@
+package interfaces_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestInterfaceValidations(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "equivalent condition written differently",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = try(var.lock.kind, "CanNotDelete") == "CanNotDelete" || try(var.lock.kind, "") == "ReadOnly"
+    error_message = "Lock kind must be either CanNotDelete or ReadOnly."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "condition depends on something else",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = var.lock == null || contains(local.lock_kinds, var.lock.kind)
+    error_message = "Invalid lock kind."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "condition using one, startswith and endswith",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = var.lock == null || one([for k in ["CanNotDelete", "ReadOnly"] : k if startswith(var.lock.kind, k) && endswith(var.lock.kind, k)]) != null
+    error_message = "Invalid lock kind."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "condition calling an unknown function",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = var.lock == null || provider::lock::is_valid_kind(var.lock.kind)
+    error_message = "Invalid lock kind."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "missing validation",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock),
+					Message: "variable validation does not comply with the interface specification: lock.kind must be one of CanNotDelete or ReadOnly, but `{ kind = \"None\" }` is accepted. Expected a condition equivalent to `var.lock != null ? contains([\"CanNotDelete\", \"ReadOnly\"], var.lock.kind) : true`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "validation too permissive",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = var.lock != null ? contains(["CanNotDelete", "ReadOnly", "None"], var.lock.kind) : true
+    error_message = "Invalid lock kind."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock),
+					Message: "variable validation does not comply with the interface specification: lock.kind must be one of CanNotDelete or ReadOnly, but `{ kind = \"None\" }` is accepted. Expected a condition equivalent to `var.lock != null ? contains([\"CanNotDelete\", \"ReadOnly\"], var.lock.kind) : true`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "validation too strict",
+			Content: `variable "lock" {
+  type = object({
+    kind = string
+    name = optional(string, null)
+  })
+  default = null
+
+  validation {
+    condition     = try(contains(["CanNotDelete", "ReadOnly"], var.lock.kind), false)
+    error_message = "Invalid lock kind."
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock),
+					Message: "variable validation does not comply with the interface specification: lock.kind must be one of CanNotDelete or ReadOnly, but `null` is rejected",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 9, Column: 5},
+						End:      hcl.Pos{Line: 9, Column: 86},
+					},
+				},
+			},
+		},
+	}
+
+	rule := interfaces.NewVarCheckRuleFromAvmInterface(interfaces.Lock)
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"github.com/Azure/tflint-ruleset-avm/rules"
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 var (
@@ -17,10 +16,6 @@ var (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "avm",
-			Version: version,
-			Rules:   rules.Rules,
-		},
+		RuleSet: rules.NewRuleSet(version),
 	})
 }
@@ -1,9 +1,17 @@
 // Package outputs provides the rules for the outputs category.
-// Add the rules to the below slice to enable them.
+// Add the rules to NewRules to enable them.
 package outputs
 
-import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+import (
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
 
-var Rules = []tflint.Rule{
-	NewRequiredOutputRule("required_output_rmfr7", "resource_id", "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-rmfr7---category-outputs---minimum-required-outputs"),
+var Rules = NewRules()
+
+// NewRules returns new instances of the rules of the outputs category.
+func NewRules() []tflint.Rule {
+	return []tflint.Rule{
+		NewRequiredOutputRule("required_output_rmfr7", "resource_id", "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-rmfr7---category-outputs---minimum-required-outputs", config.ModuleTypeResource),
+	}
 }
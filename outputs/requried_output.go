@@ -3,6 +3,7 @@ package outputs
 import (
 	"fmt"
 
+	"github.com/Azure/tflint-ruleset-avm/config"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -21,6 +22,7 @@ var outputBodySchema = &hclext.BodySchema{
 
 // Check interface compliance with the tflint.Rule.
 var _ tflint.Rule = new(RequiredOutputRule)
+var _ config.Profiled = new(RequiredOutputRule)
 
 // RequiredOutputRule is the struct that represents a rule that
 // check for the correct usage of an interface.
@@ -29,14 +31,18 @@ type RequiredOutputRule struct {
 	outputName string
 	link       string
 	ruleName   string
+	// moduleTypes are the module types the output is required for, all of them if empty.
+	moduleTypes []string
 }
 
 // NewRequiredOutputRule returns a new rule with the given variable.
-func NewRequiredOutputRule(ruleName, requiredOutputName, link string) *RequiredOutputRule {
+// The output is required for the given module types, or for all modules if none is given.
+func NewRequiredOutputRule(ruleName, requiredOutputName, link string, moduleTypes ...string) *RequiredOutputRule {
 	return &RequiredOutputRule{
-		ruleName:   ruleName,
-		outputName: requiredOutputName,
-		link:       link,
+		ruleName:    ruleName,
+		outputName:  requiredOutputName,
+		link:        link,
+		moduleTypes: moduleTypes,
 	}
 }
 
@@ -50,6 +56,14 @@ func (or *RequiredOutputRule) Link() string {
 	return or.link
 }
 
+// ModuleTypes returns the module types the rule applies to.
+func (or *RequiredOutputRule) ModuleTypes() []string {
+	if len(or.moduleTypes) == 0 {
+		return []string{config.ModuleTypeResource, config.ModuleTypePattern, config.ModuleTypeUtility}
+	}
+	return or.moduleTypes
+}
+
 // Enabled returns whether the rule is enabled.
 func (or *RequiredOutputRule) Enabled() bool {
 	return true
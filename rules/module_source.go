@@ -1,9 +1,16 @@
 package rules
 
 import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/Azure/tflint-ruleset-avm/config"
 	"github.com/hashicorp/go-multierror"
+	goverison "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -19,6 +26,9 @@ var moduleSourceBodySchema = &hclext.BodySchema{
 					{
 						Name: "source",
 					},
+					{
+						Name: "version",
+					},
 				},
 			},
 		},
@@ -26,9 +36,13 @@ var moduleSourceBodySchema = &hclext.BodySchema{
 }
 
 var _ tflint.Rule = new(ModuleSourceRule)
+var _ config.Configurable = new(ModuleSourceRule)
 
 type ModuleSourceRule struct {
 	tflint.DefaultRule
+	// AllowedSources are the module sources to accept in addition to AVM modules,
+	// either prefixes or glob patterns, e.g. `contoso/*/azurerm`.
+	AllowedSources []string
 }
 
 func NewModuleSourceRule() *ModuleSourceRule {
@@ -51,6 +65,17 @@ func (t *ModuleSourceRule) Severity() tflint.Severity {
 	return tflint.ERROR
 }
 
+// ApplyConfig reads the allowed module sources from the plugin config.
+func (t *ModuleSourceRule) ApplyConfig(c *config.Config) error {
+	for _, pattern := range c.AllowedModuleSources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid allowed module source %q: %w", pattern, err)
+		}
+	}
+	t.AllowedSources = c.AllowedModuleSources
+	return nil
+}
+
 func (t *ModuleSourceRule) Check(r tflint.Runner) error {
 	path, err := r.GetModulePath()
 	if err != nil {
@@ -91,22 +116,201 @@ func (t *ModuleSourceRule) checkBlock(r tflint.Runner, block *hclext.Block) erro
 		)
 	}
 
-	if err := r.EvaluateExpr(source.Expr, t.isAVMModule(r, source.NameRange), &tflint.EvaluateExprOption{ModuleCtx: tflint.RootModuleCtxType}); err != nil {
-		return err
+	return r.EvaluateExpr(source.Expr, func(src string) error {
+		return t.checkSource(r, block, src, source.NameRange)
+	}, &tflint.EvaluateExprOption{ModuleCtx: tflint.RootModuleCtxType})
+}
+
+// Kinds of module sources, used to explain why a source is rejected.
+const (
+	sourceKindRegistry = "registry"
+	sourceKindGit      = "git"
+	sourceKindLocal    = "local"
+	sourceKindOther    = "other"
+)
+
+// defaultAllowedGitSources are the git repositories accepted in addition to the configured ones.
+var defaultAllowedGitSources = []string{"git::https://github.com/Azure/terraform-azurerm-avm-*"}
+
+// commitSHARegexp matches a full git commit SHA.
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// checkSource accepts the local sub modules under `./modules/`, the AVM modules of the registry,
+// the AVM git repositories pinned to a commit, and the allowed sources.
+// The registry modules must pin their version.
+func (t *ModuleSourceRule) checkSource(r tflint.Runner, block *hclext.Block, source string, issueRange hcl.Range) error {
+	name := block.Labels[0]
+	switch sourceKind(source) {
+	case sourceKindLocal:
+		if msg := checkLocalSource(source); msg != "" {
+			return r.EmitIssue(t, fmt.Sprintf("The local `source` %q of module %q %s", source, name, msg), issueRange)
+		}
+		return nil
+	case sourceKindGit:
+		if !t.isAllowed(source, defaultAllowedGitSources...) {
+			return r.EmitIssue(t,
+				fmt.Sprintf("The git `source` %q of module %q should be an AVM repository, e.g. `git::https://github.com/Azure/terraform-azurerm-avm-res-storage-storageaccount.git?ref=<commit>`, or match `allowed_module_sources`", source, name),
+				issueRange)
+		}
+		if !commitSHARegexp.MatchString(gitRef(source)) {
+			return r.EmitIssue(t,
+				fmt.Sprintf("The git `source` %q of module %q should set `ref` to a full commit SHA, tags and branches can be moved", source, name),
+				issueRange)
+		}
+		return nil
+	case sourceKindRegistry:
+		rs, _ := parseRegistrySource(source)
+		if !rs.isAVM() && !t.isAllowed(source) {
+			return r.EmitIssue(t,
+				fmt.Sprintf("The registry `source` %q of module %q should be an AVM module of the `Azure` namespace, e.g. `Azure/avm-res-storage-storageaccount/azurerm`, or match `allowed_module_sources`", source, name),
+				issueRange)
+		}
+		return t.checkVersion(r, block)
+	}
+	if t.isAllowed(source) {
+		return nil
 	}
+	return r.EmitIssue(t,
+		fmt.Sprintf("The `source` %q of module %q should be a registry module, a git repository or a local path under `./modules/`", source, name),
+		issueRange)
+}
 
-	return nil
+// sourceKind returns the kind of the module source. Paths are local even if Terraform would not
+// take them as such, e.g. `modules/foo`, so that the rejection explains what is wrong with them.
+func sourceKind(source string) string {
+	switch {
+	case strings.HasPrefix(source, "./"), strings.HasPrefix(source, "../"), strings.HasPrefix(source, "modules/"):
+		return sourceKindLocal
+	case strings.HasPrefix(source, "git::"), strings.HasPrefix(source, "github.com/"), strings.HasPrefix(source, "git@"):
+		return sourceKindGit
+	}
+	if _, ok := parseRegistrySource(source); ok {
+		return sourceKindRegistry
+	}
+	return sourceKindOther
+}
+
+// checkLocalSource returns why the local source is rejected, or an empty string if it is under `./modules/`.
+func checkLocalSource(source string) string {
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return "should start with `./`, e.g. `./" + source + "`"
+	}
+	clean := path.Clean(source)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "should not escape the module directory, local modules should be under `./modules/`"
+	}
+	if !strings.HasPrefix(clean, "modules/") {
+		return "should be under `./modules/`"
+	}
+	return ""
+}
+
+// gitRef returns the `ref` query parameter of a git source, or an empty string if there is none.
+func gitRef(source string) string {
+	_, query, ok := strings.Cut(source, "?")
+	if !ok {
+		return ""
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return ""
+	}
+	return values.Get("ref")
 }
 
-func (t *ModuleSourceRule) isAVMModule(r tflint.Runner, issueRange hcl.Range) func(string) error {
-	return func(source string) error {
-		if (strings.HasPrefix(source, "Azure/") && strings.Contains(source, "avm-")) || strings.HasPrefix(source, "./modules/") {
+// isAllowed returns whether the source matches one of the allowed sources or the given patterns.
+// Patterns with glob characters are matched against the source without its query and sub directory,
+// with `*` not matching `/`. The other patterns are prefixes.
+func (t *ModuleSourceRule) isAllowed(source string, patterns ...string) bool {
+	return slices.ContainsFunc(slices.Concat(t.AllowedSources, patterns), func(pattern string) bool {
+		if !strings.ContainsAny(pattern, "*?[") {
+			return strings.HasPrefix(source, pattern)
+		}
+		ok, _ := path.Match(pattern, sourceAddress(source))
+		return ok
+	})
+}
+
+// sourceAddress returns the source without the query and the `//` sub directory, e.g.
+// `git::https://github.com/Azure/terraform-azurerm-avm-res-kv.git` for `git::https://github.com/Azure/terraform-azurerm-avm-res-kv.git//modules/x?ref=...`.
+func sourceAddress(source string) string {
+	source, _, _ = strings.Cut(source, "?")
+	scheme, rest, ok := strings.Cut(source, "://")
+	if !ok {
+		scheme, rest = "", source
+	} else {
+		scheme += "://"
+	}
+	rest, _, _ = strings.Cut(rest, "//")
+	return scheme + rest
+}
+
+// checkVersion checks that the registry module is pinned to an exact version,
+// or to a pessimistic constraint on the minor or patch version, so that the version has an upper bound.
+func (t *ModuleSourceRule) checkVersion(r tflint.Runner, block *hclext.Block) error {
+	version, exists := block.Body.Attributes["version"]
+	if !exists {
+		return r.EmitIssue(
+			t,
+			fmt.Sprintf("The `version` field should be declared in the `module` block %q to pin the registry module", block.Labels[0]),
+			block.DefRange,
+		)
+	}
+	return r.EvaluateExpr(version.Expr, func(constraint string) error {
+		if isPinnedConstraint(constraint) {
 			return nil
 		}
 		return r.EmitIssue(
 			t,
-			"The `source` property constraint should start with `Azure/` and contain `avm-` to only involve AVM Module",
-			issueRange,
+			fmt.Sprintf("The `version` of module %q should be an exact version or a pessimistic constraint such as `~> 0.5`, got %q", block.Labels[0], constraint),
+			version.Expr.Range(),
 		)
+	}, &tflint.EvaluateExprOption{ModuleCtx: tflint.RootModuleCtxType})
+}
+
+// registrySource is a module source of a module registry, `[<hostname>/]<namespace>/<name>/<provider>[//<subdir>]`.
+type registrySource struct {
+	hostname  string // Empty for the public registry.
+	namespace string
+	name      string
+	provider  string
+}
+
+var registrySourceRegexp = regexp.MustCompile(`^(?:([^/]+\.[^/]+)/)?([0-9A-Za-z_-]+)/([0-9A-Za-z_-]+)/([0-9a-z]+)(?://.*)?$`)
+
+// avmModuleNameRegexp matches the names of the AVM modules, e.g. `avm-res-storage-storageaccount`.
+var avmModuleNameRegexp = regexp.MustCompile(`^avm-(res|ptn|utl)(-[0-9a-z]+)+$`)
+
+// parseRegistrySource parses a registry module source, it returns false for the other kinds of sources.
+func parseRegistrySource(source string) (registrySource, bool) {
+	m := registrySourceRegexp.FindStringSubmatch(source)
+	if m == nil {
+		return registrySource{}, false
+	}
+	return registrySource{hostname: m[1], namespace: m[2], name: m[3], provider: m[4]}, true
+}
+
+// isAVM returns whether the source is an AVM module of the public registry.
+func (rs registrySource) isAVM() bool {
+	return (rs.hostname == "" || strings.EqualFold(rs.hostname, "registry.terraform.io")) &&
+		strings.EqualFold(rs.namespace, "Azure") &&
+		avmModuleNameRegexp.MatchString(rs.name)
+}
+
+// isPinnedConstraint returns whether the version constraint is an exact version, e.g. `0.5.3` or `= 0.5.3`,
+// or a pessimistic constraint with at least a minor version, e.g. `~> 0.5`. A constraint like `~> 1`
+// is rejected, as it allows any later version.
+func isPinnedConstraint(constraint string) bool {
+	if _, err := goverison.NewConstraint(constraint); err != nil || strings.Contains(constraint, ",") {
+		return false
+	}
+	c := strings.TrimSpace(constraint)
+	switch {
+	case strings.HasPrefix(c, "~>"):
+		return strings.Contains(strings.TrimSpace(strings.TrimPrefix(c, "~>")), ".")
+	case strings.HasPrefix(c, "="):
+		c = strings.TrimPrefix(c, "=")
 	}
+	_, err := goverison.NewVersion(strings.TrimSpace(c))
+	return err == nil
 }
@@ -9,9 +9,10 @@ import (
 
 func TestModuleSource(t *testing.T) {
 	cases := []struct {
-		desc   string
-		config string
-		issues helper.Issues
+		desc    string
+		config  string
+		allowed []string
+		issues  helper.Issues
 	}{
 		{
 			desc: "source exists, ok",
@@ -22,12 +23,85 @@ func TestModuleSource(t *testing.T) {
 			issues: helper.Issues{},
 		},
 		{
-			desc: "no version, ok",
+			desc: "pessimistic constraint, ok",
 			config: `module "other-module" {
-  source  = "Azure/avm-res-keyvault-vault/azurerm"
+  source  = "registry.terraform.io/Azure/avm-res-storage-storageaccount/azurerm//modules/container"
+  version = "~> 0.2"
 }`,
 			issues: helper.Issues{},
 		},
+		{
+			desc: "exact constraint, ok",
+			config: `module "other-module" {
+  source  = "Azure/avm-utl-regions/azurerm"
+  version = "= 0.3.0"
+}`,
+			issues: helper.Issues{},
+		},
+		{
+			desc: "no version, not ok",
+			config: `module "other-module" {
+  source  = "Azure/avm-res-keyvault-vault/azurerm"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The `version` field should be declared in the `module` block \"other-module\" to pin the registry module",
+				},
+			},
+		},
+		{
+			desc: "lower bound only, not ok",
+			config: `module "other-module" {
+  source  = "Azure/avm-res-keyvault-vault/azurerm"
+  version = ">= 0.5.0"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The `version` of module \"other-module\" should be an exact version or a pessimistic constraint such as `~> 0.5`, got \">= 0.5.0\"",
+				},
+			},
+		},
+		{
+			desc: "pessimistic constraint on the major version, not ok",
+			config: `module "other-module" {
+  source  = "Azure/avm-res-keyvault-vault/azurerm"
+  version = "~> 1"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The `version` of module \"other-module\" should be an exact version or a pessimistic constraint such as `~> 0.5`, got \"~> 1\"",
+				},
+			},
+		},
+		{
+			desc: "avm lookalike, not ok",
+			config: `module "other-module" {
+  source  = "Azure/not-avm-foo/azurerm"
+  version = "1.0.0"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The registry `source` \"Azure/not-avm-foo/azurerm\" of module \"other-module\" should be an AVM module of the `Azure` namespace, e.g. `Azure/avm-res-storage-storageaccount/azurerm`, or match `allowed_module_sources`",
+				},
+			},
+		},
+		{
+			desc: "avm name in another namespace, not ok",
+			config: `module "other-module" {
+  source  = "contoso/avm-res-keyvault-vault/azurerm"
+  version = "1.0.0"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The registry `source` \"contoso/avm-res-keyvault-vault/azurerm\" of module \"other-module\" should be an AVM module of the `Azure` namespace, e.g. `Azure/avm-res-storage-storageaccount/azurerm`, or match `allowed_module_sources`",
+				},
+			},
+		},
 		{
 			desc: "no source, not ok",
 			config: `module "other-module" {
@@ -48,7 +122,7 @@ func TestModuleSource(t *testing.T) {
 			issues: helper.Issues{
 				{
 					Rule:    rules.NewModuleSourceRule(),
-					Message: "The `source` property constraint should start with `Azure/` and contain `avm-` to only involve AVM Module",
+					Message: "The git `source` \"git::https://Azure/terraform-azurerm-avm-res-keyvault-vault.git\" of module \"other-module\" should be an AVM repository, e.g. `git::https://github.com/Azure/terraform-azurerm-avm-res-storage-storageaccount.git?ref=<commit>`, or match `allowed_module_sources`",
 				},
 			},
 		},
@@ -61,7 +135,7 @@ func TestModuleSource(t *testing.T) {
 			issues: helper.Issues{
 				{
 					Rule:    rules.NewModuleSourceRule(),
-					Message: "The `source` property constraint should start with `Azure/` and contain `avm-` to only involve AVM Module",
+					Message: "The git `source` \"github.com/Azure/terraform-azurerm-avm-res-keyvault-vault\" of module \"other-module\" should be an AVM repository, e.g. `git::https://github.com/Azure/terraform-azurerm-avm-res-storage-storageaccount.git?ref=<commit>`, or match `allowed_module_sources`",
 				},
 			},
 		},
@@ -69,10 +143,129 @@ func TestModuleSource(t *testing.T) {
 			desc: "source sub module, ok",
 			config: `module "other-module" {
   source  = "./modules/my-sub-module"
-  version = "0.5.3"
 }`,
 			issues: helper.Issues{},
 		},
+		{
+			desc: "avm git repository pinned to a commit, ok",
+			config: `module "other-module" {
+  source = "git::https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault.git//modules/secret?ref=0f8c4b1b7a1c9e6d2f3a4b5c6d7e8f9a0b1c2d3e"
+}`,
+			issues: helper.Issues{},
+		},
+		{
+			desc: "avm git repository pinned to a tag, not ok",
+			config: `module "other-module" {
+  source = "git::https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault.git?ref=v0.5.3"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The git `source` \"git::https://github.com/Azure/terraform-azurerm-avm-res-keyvault-vault.git?ref=v0.5.3\" of module \"other-module\" should set `ref` to a full commit SHA, tags and branches can be moved",
+				},
+			},
+		},
+		{
+			desc: "allowed git repository from plugin config, ok",
+			config: `module "other-module" {
+  source = "git::https://dev.azure.com/contoso/modules/_git/network?ref=0f8c4b1b7a1c9e6d2f3a4b5c6d7e8f9a0b1c2d3e"
+}`,
+			allowed: []string{"git::https://dev.azure.com/contoso/*/_git/*"},
+			issues:  helper.Issues{},
+		},
+		{
+			desc: "local source escaping the module, not ok",
+			config: `module "other-module" {
+  source = "../shared"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The local `source` \"../shared\" of module \"other-module\" should not escape the module directory, local modules should be under `./modules/`",
+				},
+			},
+		},
+		{
+			desc: "local source escaping the modules directory, not ok",
+			config: `module "other-module" {
+  source = "./modules/../shared"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The local `source` \"./modules/../shared\" of module \"other-module\" should be under `./modules/`",
+				},
+			},
+		},
+		{
+			desc: "local source without ./, not ok",
+			config: `module "other-module" {
+  source = "modules/my-sub-module"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The local `source` \"modules/my-sub-module\" of module \"other-module\" should start with `./`, e.g. `./modules/my-sub-module`",
+				},
+			},
+		},
+		{
+			desc: "archive source, not ok",
+			config: `module "other-module" {
+  source = "https://example.com/vpc-module.zip"
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The `source` \"https://example.com/vpc-module.zip\" of module \"other-module\" should be a registry module, a git repository or a local path under `./modules/`",
+				},
+			},
+		},
+		{
+			desc: "allowed registry glob from plugin config, ok",
+			config: `module "other-module" {
+  source  = "contoso/network-vnet/azurerm"
+  version = "~> 1.2"
+}`,
+			allowed: []string{"contoso/network-*/azurerm"},
+			issues:  helper.Issues{},
+		},
+		{
+			desc: "allowed source from plugin config, ok",
+			config: `module "other-module" {
+  source  = "contoso/internal-module/azurerm"
+  version = "1.0.0"
+}`,
+			allowed: []string{"contoso/"},
+			issues:  helper.Issues{},
+		},
+		{
+			desc: "allowed registry source must pin its version, not ok",
+			config: `module "other-module" {
+  source  = "contoso/internal-module/azurerm"
+}`,
+			allowed: []string{"contoso/"},
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The `version` field should be declared in the `module` block \"other-module\" to pin the registry module",
+				},
+			},
+		},
+		{
+			desc: "source not in allowed sources from plugin config, not ok",
+			config: `module "other-module" {
+  source  = "fabrikam/internal-module/azurerm"
+  version = "1.0.0"
+}`,
+			allowed: []string{"contoso/"},
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewModuleSourceRule(),
+					Message: "The registry `source` \"fabrikam/internal-module/azurerm\" of module \"other-module\" should be an AVM module of the `Azure` namespace, e.g. `Azure/avm-res-storage-storageaccount/azurerm`, or match `allowed_module_sources`",
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -80,6 +273,7 @@ func TestModuleSource(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			t.Parallel()
 			rule := rules.NewModuleSourceRule()
+			rule.AllowedSources = tc.allowed
 			filename := "terraform.tf"
 
 			runner := helper.TestRunner(t, map[string]string{filename: tc.config})
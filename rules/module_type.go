@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleNameRegexp matches the AVM module name prefixes, e.g. `avm-res-` in `terraform-azurerm-avm-res-keyvault-vault`.
+var moduleNameRegexp = regexp.MustCompile(`(?:^|[-/])avm-(res|ptn|utl)-`)
+
+var moduleNamePrefixes = map[string]string{
+	"res": config.ModuleTypeResource,
+	"ptn": config.ModuleTypePattern,
+	"utl": config.ModuleTypeUtility,
+}
+
+// resolveModuleType returns the type of the module being checked. The configured type wins,
+// otherwise it is inferred from the name of the module directory, then from the module source
+// reported by the telemetry resource. An empty type is returned if it cannot be inferred.
+func resolveModuleType(runner tflint.Runner, configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+	files, err := runner.GetFiles()
+	if err != nil {
+		return "", err
+	}
+	wd, err := runner.GetOriginalwd()
+	if err != nil {
+		return "", err
+	}
+	dir := wd
+	if names := sortedFileNames(files); len(names) > 0 {
+		// The file names are relative to the original working directory.
+		// The first name is used, so that the result does not depend on the map order.
+		dir = filepath.Join(wd, filepath.Dir(names[0]))
+	}
+	if t := moduleTypeFromName(filepath.Base(dir)); t != "" {
+		return t, nil
+	}
+	return moduleTypeFromTelemetry(files), nil
+}
+
+// sortedFileNames returns the names of the files in order.
+func sortedFileNames(files map[string]*hcl.File) []string {
+	return slices.Sorted(maps.Keys(files))
+}
+
+// moduleTypeFromName returns the module type matching the AVM prefix in the name, or an empty string.
+func moduleTypeFromName(name string) string {
+	m := moduleNameRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return ""
+	}
+	return moduleNamePrefixes[m[1]]
+}
+
+// moduleTypeFromTelemetry returns the module type matching the AVM prefix in the string literals
+// of the `modtm_telemetry` tags, e.g. `module_source = "registry.terraform.io/Azure/avm-res-keyvault-vault/azurerm"`.
+func moduleTypeFromTelemetry(files map[string]*hcl.File) string {
+	names := sortedFileNames(files)
+
+	for _, name := range names {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, b := range body.Blocks {
+			if b.Type != "resource" || len(b.Labels) != 2 || b.Labels[0] != "modtm_telemetry" {
+				continue
+			}
+			tags, ok := b.Body.Attributes["tags"]
+			if !ok {
+				continue
+			}
+			moduleType := ""
+			_ = hclsyntax.VisitAll(tags.Expr, func(node hclsyntax.Node) hcl.Diagnostics {
+				lit, ok := node.(*hclsyntax.LiteralValueExpr)
+				if moduleType != "" || !ok || lit.Val.Type() != cty.String || lit.Val.IsNull() {
+					return nil
+				}
+				moduleType = moduleTypeFromName(lit.Val.AsString())
+				return nil
+			})
+			if moduleType != "" {
+				return moduleType
+			}
+		}
+	}
+	return ""
+}
+
+// ruleAppliesTo returns whether the rule applies to the module type.
+// All rules apply when the module type is unknown.
+func ruleAppliesTo(rule tflint.Rule, moduleType string) bool {
+	p, ok := rule.(config.Profiled)
+	return !ok || moduleType == "" || slices.Contains(p.ModuleTypes(), moduleType)
+}
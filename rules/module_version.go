@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	goverison "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = new(ModuleVersionRule)
+
+// moduleVersionFile is the file that holds the version of the module, reported by the telemetry resource.
+const moduleVersionFile = "locals.version.tf.json"
+
+var moduleVersionSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "locals"}},
+}
+
+// ModuleVersionRule checks the `locals.version.tf.json` file of the root module, which must exist.
+// The file must only declare the `module_version` local, set to a semantic version,
+// and the version must be reported in the tags of the `modtm_telemetry` resource.
+type ModuleVersionRule struct {
+	tflint.DefaultRule
+}
+
+func NewModuleVersionRule() *ModuleVersionRule {
+	return new(ModuleVersionRule)
+}
+
+func (m *ModuleVersionRule) Name() string {
+	return "module_version"
+}
+
+func (m *ModuleVersionRule) Link() string {
+	return "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-sfr3---category-telemetry---deploymentusage-telemetry"
+}
+
+func (m *ModuleVersionRule) Enabled() bool {
+	return true
+}
+
+func (m *ModuleVersionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (m *ModuleVersionRule) Check(r tflint.Runner) error {
+	path, err := r.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+	files, err := r.GetFiles()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	found := false
+	for _, name := range names {
+		switch filepath.Base(name) {
+		case strings.TrimSuffix(moduleVersionFile, ".json"):
+			found = true
+			start := hcl.Range{Filename: name, Start: hcl.InitialPos, End: hcl.InitialPos}
+			if err := r.EmitIssue(m, fmt.Sprintf("`%s` must use the JSON syntax, rename it to `%s`", filepath.Base(name), moduleVersionFile), start); err != nil {
+				return err
+			}
+		case moduleVersionFile:
+			found = true
+			if err := m.checkVersionFile(r, files, name); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return r.EmitIssue(m, fmt.Sprintf("All avm Terraform modules must contain `%s` file, declaring the `module_version` local", moduleVersionFile), hcl.Range{})
+	}
+	return nil
+}
+
+// checkVersionFile checks the content of the version file and that the version is reported by the telemetry.
+func (m *ModuleVersionRule) checkVersionFile(r tflint.Runner, files map[string]*hcl.File, name string) error {
+	content, diags := files[name].Body.Content(moduleVersionSchema)
+	for _, diag := range diags {
+		if diag.Subject == nil {
+			continue
+		}
+		if err := r.EmitIssue(m, fmt.Sprintf("`%s` must only declare the `module_version` local: %s", moduleVersionFile, diag.Detail), *diag.Subject); err != nil {
+			return err
+		}
+	}
+	if diags.HasErrors() {
+		return nil
+	}
+
+	var version *hcl.Attribute
+	for _, b := range content.Blocks {
+		attrs, diags := b.Body.JustAttributes()
+		if diags.HasErrors() {
+			return diags
+		}
+		for _, attr := range attrs {
+			if attr.Name == "module_version" {
+				version = attr
+				continue
+			}
+			if err := r.EmitIssue(m, fmt.Sprintf("`%s` must only declare the `module_version` local, got `%s`", moduleVersionFile, attr.Name), attr.Range); err != nil {
+				return err
+			}
+		}
+	}
+	if version == nil {
+		return r.EmitIssue(m, fmt.Sprintf("`%s` must declare the `module_version` local", moduleVersionFile),
+			hcl.Range{Filename: name, Start: hcl.InitialPos, End: hcl.InitialPos})
+	}
+
+	val, diags := version.Expr.Value(nil)
+	if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+		return r.EmitIssue(m, "`module_version` must be a string", version.Expr.Range())
+	}
+	if !isSemver(val.AsString()) {
+		return r.EmitIssue(m, fmt.Sprintf("`module_version` must be a semantic version, e.g. `1.0.0`, got %q", val.AsString()), version.Expr.Range())
+	}
+
+	if !telemetryReportsVersion(files) {
+		return r.EmitIssue(m, "`local.module_version` must be reported in the tags of the `modtm_telemetry` resource", version.Range)
+	}
+	return nil
+}
+
+// isSemver returns whether the string is a semantic version with the major, minor and patch numbers,
+// e.g. `1.2.3` or `1.0.0-beta.1`. The `v` prefix accepted by go-version is not allowed.
+func isSemver(s string) bool {
+	if _, err := goverison.NewSemver(s); err != nil || strings.HasPrefix(s, "v") {
+		return false
+	}
+	core, _, _ := strings.Cut(s, "+")
+	core, _, _ = strings.Cut(core, "-")
+	return strings.Count(core, ".") == 2
+}
+
+// telemetryReportsVersion returns whether the tags of a `modtm_telemetry` resource refer to `local.module_version`.
+func telemetryReportsVersion(files map[string]*hcl.File) bool {
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, b := range body.Blocks {
+			if b.Type != "resource" || len(b.Labels) != 2 || b.Labels[0] != "modtm_telemetry" {
+				continue
+			}
+			tags, ok := b.Body.Attributes["tags"]
+			if !ok {
+				continue
+			}
+			for _, traversal := range tags.Expr.Variables() {
+				if traversal.RootName() != "local" || len(traversal) < 2 {
+					continue
+				}
+				if step, ok := traversal[1].(hcl.TraverseAttr); ok && step.Name == "module_version" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const moduleVersionTelemetry = `resource "modtm_telemetry" "telemetry" {
+  count = var.enable_telemetry ? 1 : 0
+
+  tags = {
+    module_version = local.module_version
+  }
+}`
+
+func TestModuleVersionRule(t *testing.T) {
+	rule := rules.NewModuleVersionRule()
+
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "correct",
+			Files: map[string]string{
+				"locals.version.tf.json": `{"locals": {"module_version": "0.3.1"}}`,
+				"main.telemetry.tf":      moduleVersionTelemetry,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name:  "no version file",
+			Files: map[string]string{"main.tf": `resource "azurerm_key_vault" "this" {}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "All avm Terraform modules must contain `locals.version.tf.json` file, declaring the `module_version` local",
+					Range:   hcl.Range{},
+				},
+			},
+		},
+		{
+			Name: "native syntax",
+			Files: map[string]string{
+				"locals.version.tf": `locals {
+  module_version = "0.3.1"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "`locals.version.tf` must use the JSON syntax, rename it to `locals.version.tf.json`",
+					Range:   hcl.Range{Filename: "locals.version.tf", Start: hcl.InitialPos, End: hcl.InitialPos},
+				},
+			},
+		},
+		{
+			Name: "other content",
+			Files: map[string]string{
+				"locals.version.tf.json": `{"locals": {"module_version": "0.3.1", "name": "kv"}}`,
+				"main.telemetry.tf":      moduleVersionTelemetry,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "`locals.version.tf.json` must only declare the `module_version` local, got `name`",
+					Range: hcl.Range{
+						Filename: "locals.version.tf.json",
+						Start:    hcl.Pos{Line: 1, Column: 40, Byte: 39},
+						End:      hcl.Pos{Line: 1, Column: 52, Byte: 51},
+					},
+				},
+			},
+		},
+		{
+			Name: "invalid version",
+			Files: map[string]string{
+				"locals.version.tf.json": `{"locals": {"module_version": "v1.0"}}`,
+				"main.telemetry.tf":      moduleVersionTelemetry,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "`module_version` must be a semantic version, e.g. `1.0.0`, got \"v1.0\"",
+					Range: hcl.Range{
+						Filename: "locals.version.tf.json",
+						Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
+						End:      hcl.Pos{Line: 1, Column: 37, Byte: 36},
+					},
+				},
+			},
+		},
+		{
+			Name: "version not reported",
+			Files: map[string]string{
+				"locals.version.tf.json": `{"locals": {"module_version": "1.0.0-beta.1"}}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "`local.module_version` must be reported in the tags of the `modtm_telemetry` resource",
+					Range: hcl.Range{
+						Filename: "locals.version.tf.json",
+						Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+						End:      hcl.Pos{Line: 1, Column: 45, Byte: 44},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			runner := helper.TestRunner(t, tc.Files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
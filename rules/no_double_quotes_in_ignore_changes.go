@@ -2,9 +2,11 @@ package rules
 
 import (
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var noDoubleQuotesInIgnoreChangesBodySchema = &hclext.BodySchema{
@@ -97,15 +99,42 @@ func (t *NoDoubleQuotesInIgnoreChangesRule) checkBlock(r tflint.Runner, block *h
 		}
 
 		for _, itemExpr := range ignoreChangesExpr.Exprs {
-			if _, ok := itemExpr.(*hclsyntax.ScopeTraversalExpr); !ok {
-				return r.EmitIssue(
-					t,
-					"ignore_changes shouldn't include double quotes",
-					ignoreChanges.Range,
-				)
+			if _, ok := itemExpr.(*hclsyntax.ScopeTraversalExpr); ok {
+				continue
+			}
+			if err := r.EmitIssueWithFix(
+				t,
+				"ignore_changes shouldn't include double quotes",
+				itemExpr.Range(),
+				fixDoubleQuotes(itemExpr),
+			); err != nil {
+				return err
 			}
 		}
 	}
-
 	return nil
 }
+
+// fixDoubleQuotes returns a fix function that replaces a quoted ignore_changes entry, e.g. `"tags"`,
+// with the bare traversal `tags`. Entries that are not a literal string holding a valid traversal cannot be fixed.
+func fixDoubleQuotes(expr hclsyntax.Expression) func(f tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		tpl, ok := expr.(*hclsyntax.TemplateExpr)
+		if !ok || !tpl.IsStringLiteral() {
+			return tflint.ErrFixNotSupported
+		}
+		val, diags := tpl.Value(nil)
+		if diags.HasErrors() || !val.Type().Equals(cty.String) || val.IsNull() {
+			return tflint.ErrFixNotSupported
+		}
+		traversal := val.AsString()
+		parsed, diags := hclsyntax.ParseExpression([]byte(traversal), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			return tflint.ErrFixNotSupported
+		}
+		if _, ok := parsed.(*hclsyntax.ScopeTraversalExpr); !ok {
+			return tflint.ErrFixNotSupported
+		}
+		return f.ReplaceText(expr.Range(), traversal)
+	}
+}
@@ -4,14 +4,16 @@ import (
 	"testing"
 
 	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
 func TestNoDoubleQuotesInIgnoreChanges(t *testing.T) {
 	cases := []struct {
-		desc   string
-		config string
-		issues helper.Issues
+		desc    string
+		config  string
+		issues  helper.Issues
+		changes map[string]string
 	}{
 		{
 			desc: "no double quotes, ok",
@@ -23,7 +25,8 @@ func TestNoDoubleQuotesInIgnoreChanges(t *testing.T) {
     ignore_changes = [tags, location]
   }
 }`,
-			issues: helper.Issues{},
+			issues:  helper.Issues{},
+			changes: map[string]string{},
 		},
 		{
 			desc: "double quotes exist, not ok",
@@ -39,8 +42,32 @@ func TestNoDoubleQuotesInIgnoreChanges(t *testing.T) {
 				{
 					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
 					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 6, Column: 23, Byte: 109},
+						End:      hcl.Pos{Line: 6, Column: 29, Byte: 115},
+					},
+				},
+				{
+					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
+					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 6, Column: 31, Byte: 117},
+						End:      hcl.Pos{Line: 6, Column: 41, Byte: 127},
+					},
 				},
 			},
+			changes: map[string]string{
+				"terraform.tf": `resource "azurerm_resource_group" "test" {
+  name     = "acctestRG"
+  location = "westeurope"
+
+  lifecycle {
+    ignore_changes = [tags, location]
+  }
+}`,
+			},
 		},
 		{
 			desc: "some item includes double quotes, not ok",
@@ -56,8 +83,78 @@ func TestNoDoubleQuotesInIgnoreChanges(t *testing.T) {
 				{
 					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
 					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 6, Column: 23, Byte: 109},
+						End:      hcl.Pos{Line: 6, Column: 29, Byte: 115},
+					},
+				},
+			},
+			changes: map[string]string{
+				"terraform.tf": `resource "azurerm_resource_group" "test" {
+  name     = "acctestRG"
+  location = "westeurope"
+
+  lifecycle {
+    ignore_changes = [tags, location]
+  }
+}`,
+			},
+		},
+		{
+			desc: "nested paths in double quotes, not ok",
+			config: `resource "azurerm_linux_web_app" "test" {
+  lifecycle {
+    ignore_changes = ["tags[\"x\"]", "site_config.0.foo"]
+  }
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
+					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 3, Column: 23, Byte: 79},
+						End:      hcl.Pos{Line: 3, Column: 36, Byte: 92},
+					},
+				},
+				{
+					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
+					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 3, Column: 38, Byte: 94},
+						End:      hcl.Pos{Line: 3, Column: 57, Byte: 113},
+					},
+				},
+			},
+			changes: map[string]string{
+				"terraform.tf": `resource "azurerm_linux_web_app" "test" {
+  lifecycle {
+    ignore_changes = [tags["x"], site_config.0.foo]
+  }
+}`,
+			},
+		},
+		{
+			desc: "interpolated template, not ok and not fixable",
+			config: `resource "azurerm_resource_group" "test" {
+  lifecycle {
+    ignore_changes = ["${local.attr}"]
+  }
+}`,
+			issues: helper.Issues{
+				{
+					Rule:    rules.NewNoDoubleQuotesInIgnoreChangesRule(),
+					Message: "ignore_changes shouldn't include double quotes",
+					Range: hcl.Range{
+						Filename: "terraform.tf",
+						Start:    hcl.Pos{Line: 3, Column: 23, Byte: 80},
+						End:      hcl.Pos{Line: 3, Column: 38, Byte: 95},
+					},
 				},
 			},
+			changes: map[string]string{},
 		},
 	}
 
@@ -74,7 +171,8 @@ func TestNoDoubleQuotesInIgnoreChanges(t *testing.T) {
 				t.Fatalf("Unexpected error occurred: %s", err)
 			}
 
-			helper.AssertIssuesWithoutRange(t, tc.issues, runner.Issues)
+			helper.AssertIssues(t, tc.issues, runner.Issues)
+			helper.AssertChanges(t, tc.changes, runner.Changes())
 		})
 	}
 }
@@ -2,8 +2,11 @@ package rules
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/Azure/tflint-ruleset-avm/config"
 	goverison "github.com/hashicorp/go-version"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -11,6 +14,7 @@ import (
 )
 
 var _ tflint.Rule = new(ProviderVersionRule)
+var _ config.Configurable = new(ProviderVersionRule)
 
 type ProviderVersionRule struct {
 	tflint.DefaultRule
@@ -43,6 +47,27 @@ func (m *ProviderVersionRule) Severity() tflint.Severity {
 	return tflint.ERROR
 }
 
+// ApplyConfig overrides the version target with the matching `provider` block of the plugin config, if any.
+func (m *ProviderVersionRule) ApplyConfig(c *config.Config) error {
+	p := c.Provider(m.ProviderName)
+	if p == nil {
+		return nil
+	}
+	if p.Version != "" {
+		if _, err := goverison.NewVersion(p.Version); err != nil {
+			return fmt.Errorf("invalid version for provider %s: %s", m.ProviderName, err)
+		}
+		m.Version = p.Version
+	}
+	if p.RecommendedConstraint != "" {
+		m.RecommendedConstraint = p.RecommendedConstraint
+	}
+	if p.MustExist != nil {
+		m.MustExist = *p.MustExist
+	}
+	return nil
+}
+
 func (m *ProviderVersionRule) Check(r tflint.Runner) error {
 	ver, err := goverison.NewVersion(m.Version)
 	if err != nil {
@@ -103,6 +128,11 @@ func (m *ProviderVersionRule) Check(r tflint.Runner) error {
 			if err != nil {
 				return fmt.Errorf("invalid version constraint: %s", err)
 			}
+			if msg := checkConstraintBounds(constraint); msg != "" {
+				if err = r.EmitIssue(m, fmt.Sprintf("provider `%s`'s version constraint %s %s. Recommended version constraint `%s`", m.ProviderName, provider.Version, msg, m.RecommendedConstraint), providerAttr.Range); err != nil {
+					return err
+				}
+			}
 			if constraint.Check(ver) {
 				continue
 			}
@@ -119,3 +149,95 @@ func (m *ProviderVersionRule) Check(r tflint.Runner) error {
 	}
 	return nil
 }
+
+// versionBound is a lower or upper bound of a version constraint.
+type versionBound struct {
+	version   *goverison.Version
+	inclusive bool
+}
+
+// constraintRegexp splits a single version constraint, e.g. `>= 4.0`, into its operator and version.
+var constraintRegexp = regexp.MustCompile(`^\s*(=|!=|>=|<=|>|<|~>)?\s*(\S+)\s*$`)
+
+// constraintBounds returns the tightest lower and upper bounds of the constraint set, nil if there is none.
+// A pessimistic constraint bounds both sides, e.g. `~> 4.1` is `>= 4.1, < 5.0.0`, unless it has
+// a single segment, e.g. `~> 4` has no upper bound. Exclusions with `!=` do not bound the versions.
+func constraintBounds(cs goverison.Constraints) (lower, upper *versionBound) {
+	for _, c := range cs {
+		m := constraintRegexp.FindStringSubmatch(c.String())
+		if m == nil {
+			continue
+		}
+		v, err := goverison.NewVersion(m[2])
+		if err != nil {
+			continue
+		}
+		var lo, up *versionBound
+		switch m[1] {
+		case "", "=":
+			lo, up = &versionBound{v, true}, &versionBound{v, true}
+		case ">=":
+			lo = &versionBound{v, true}
+		case ">":
+			lo = &versionBound{v, false}
+		case "<=":
+			up = &versionBound{v, true}
+		case "<":
+			up = &versionBound{v, false}
+		case "~>":
+			lo = &versionBound{v, true}
+			up = pessimisticUpperBound(m[2], v)
+		}
+		if lo != nil && (lower == nil || lo.version.GreaterThan(lower.version)) {
+			lower = lo
+		}
+		if up != nil && (upper == nil || up.version.LessThan(upper.version)) {
+			upper = up
+		}
+	}
+	return lower, upper
+}
+
+// pessimisticUpperBound returns the exclusive upper bound of `~> raw`, or nil if raw has a single segment.
+func pessimisticUpperBound(raw string, v *goverison.Version) *versionBound {
+	core, _, _ := strings.Cut(raw, "-")
+	n := strings.Count(core, ".") + 1
+	if n < 2 {
+		return nil
+	}
+	segments := v.Segments()
+	bumped := make([]string, n-1)
+	for i := range bumped {
+		bumped[i] = strconv.Itoa(segments[i])
+	}
+	last, _ := strconv.Atoi(bumped[n-2])
+	bumped[n-2] = strconv.Itoa(last + 1)
+	up, err := goverison.NewVersion(strings.Join(bumped, "."))
+	if err != nil {
+		return nil
+	}
+	return &versionBound{up, false}
+}
+
+// checkConstraintBounds returns why the constraint set does not have both a minimum version and a maximum major version,
+// or an empty string. The upper bound must not allow a major version above the one of the lower bound.
+func checkConstraintBounds(cs goverison.Constraints) string {
+	lower, upper := constraintBounds(cs)
+	switch {
+	case lower == nil && upper == nil:
+		return "has neither a lower nor an upper bound"
+	case lower == nil:
+		return "has no lower bound, add a minimum version"
+	case upper == nil:
+		return "has no upper bound, cap the major version"
+	}
+	major := lower.version.Segments()[0]
+	maxMajor, err := goverison.NewVersion(strconv.Itoa(major + 1))
+	if err != nil {
+		return ""
+	}
+	if upper.version.Segments()[0] > major+1 || (upper.version.Segments()[0] == major+1 && (upper.inclusive || upper.version.GreaterThan(maxMajor))) {
+		return fmt.Sprintf("has an upper bound %s that is too loose, it allows major versions above %d", upper.version.Original(), major)
+	}
+	return ""
+}
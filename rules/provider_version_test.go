@@ -1,6 +1,7 @@
 package rules_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Azure/tflint-ruleset-avm/rules"
@@ -107,6 +108,10 @@ func TestModtmProviderVersionRule(t *testing.T) {
 }`,
 			rule: rules.NewProviderVersionRule("modtm", "Azure/modtm", "0.2.0", "~> 0.2", true),
 			expected: helper.Issues{
+				{
+					Rule:    rules.NewProviderVersionRule("modtm", "Azure/modtm", "1.0.0", "~> 0.3", true),
+					Message: "provider `modtm`'s version constraint >= 0.3.0 has no upper bound, cap the major version. Recommended version constraint `~> 0.2`",
+				},
 				{
 					Rule:    rules.NewProviderVersionRule("modtm", "Azure/modtm", "1.0.0", "~> 0.3", true),
 					Message: "provider `modtm`'s version should satisfy 0.2.0, got >= 0.3.0. Recommended version constraint `~> 0.2`",
@@ -150,3 +155,80 @@ func TestModtmProviderVersionRule(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderVersionRuleBounds(t *testing.T) {
+	cases := []struct {
+		desc       string
+		constraint string
+		message    string
+	}{
+		{
+			desc:       "pessimistic constraint on the minor version",
+			constraint: "~> 4.0",
+		},
+		{
+			desc:       "range within a major version",
+			constraint: ">= 4.1, < 5.0",
+		},
+		{
+			desc:       "exact version",
+			constraint: "4.10.0",
+		},
+		{
+			desc:       "no upper bound",
+			constraint: ">= 3.0",
+			message:    "has no upper bound, cap the major version",
+		},
+		{
+			desc:       "pessimistic constraint on the major version",
+			constraint: "~> 4",
+			message:    "has no upper bound, cap the major version",
+		},
+		{
+			desc:       "no lower bound",
+			constraint: "< 5.0",
+			message:    "has no lower bound, add a minimum version",
+		},
+		{
+			desc:       "exclusion only",
+			constraint: "!= 4.1.0",
+			message:    "has neither a lower nor an upper bound",
+		},
+		{
+			desc:       "range over two major versions",
+			constraint: ">= 3.0, < 5.0",
+			message:    "has an upper bound 5.0 that is too loose, it allows major versions above 3",
+		},
+		{
+			desc:       "inclusive upper bound on the next major version",
+			constraint: ">= 4.0, <= 5.0.0",
+			message:    "has an upper bound 5.0.0 that is too loose, it allows major versions above 4",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			rule := rules.NewProviderVersionRule("azurerm", "hashicorp/azurerm", "4.10.0", "~> 4.0", false)
+			runner := helper.TestRunner(t, map[string]string{"main.tf": fmt.Sprintf(`terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = %q
+    }
+  }
+}`, c.constraint)})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			expected := helper.Issues{}
+			if c.message != "" {
+				expected = helper.Issues{
+					{
+						Rule:    rule,
+						Message: fmt.Sprintf("provider `azurerm`'s version constraint %s %s. Recommended version constraint `~> 4.0`", c.constraint, c.message),
+					},
+				}
+			}
+			helper.AssertIssuesWithoutRange(t, expected, runner.Issues)
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package rules
 
 import (
-	"slices"
-
 	"github.com/Azure/tflint-ruleset-avm/interfaces"
 	"github.com/Azure/tflint-ruleset-avm/outputs"
 	azurerm "github.com/Azure/tflint-ruleset-azurerm-ext/rules"
@@ -10,9 +8,15 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-var Rules = func() []tflint.Rule {
-	return slices.Concat(
-		[]tflint.Rule{
+// categoryNames are the rule categories, in the order their rules are registered.
+var categoryNames = []string{"basic", "code_style", "composition", "providers", "interfaces", "outputs"}
+
+// NewCategories returns new instances of the rules, grouped by category so that they can be
+// toggled together with a `category` block in the plugin configuration.
+// Each rule set has its own instances, as the plugin configuration is applied to the rules.
+func NewCategories() map[string][]tflint.Rule {
+	return map[string][]tflint.Rule{
+		"basic": {
 			Wrap(basic.NewTerraformHeredocUsageRule()),
 			Wrap(basic.NewTerraformModuleProviderDeclarationRule()),
 			Wrap(basic.NewTerraformOutputSeparateRule()),
@@ -22,17 +26,38 @@ var Rules = func() []tflint.Rule {
 			Wrap(basic.NewTerraformVariableNullableFalseRule()),
 			Wrap(basic.NewTerraformVariableSeparateRule()),
 			Wrap(azurerm.NewAzurermResourceTagRule()),
+		},
+		"code_style": {
 			NewTerraformDotTfRule(),
-			NewModuleSourceRule(),
 			NewNoDoubleQuotesInIgnoreChangesRule(),
+			NewModuleVersionRule(),
+		},
+		"composition": {
+			NewModuleSourceRule(),
+		},
+		"providers": {
 			NewProviderVersionRule("modtm", "Azure/modtm", "0.3.0", "~> 0.3", true),
 			NewProviderVersionRule("azapi", "Azure/azapi", "2.999.0", "~> 2.0", false),
 			NewProviderVersionRule("azurerm", "hashicorp/azurerm", "4.999.0", "~> 4.0", false),
 		},
-		interfaces.Rules,
-		outputs.Rules,
-	)
-}()
+		"interfaces": interfaces.NewRules(),
+		"outputs":    outputs.NewRules(),
+	}
+}
+
+// categoryRules returns the rules of all the categories, in the order of categoryNames.
+func categoryRules(categories map[string][]tflint.Rule) []tflint.Rule {
+	var rules []tflint.Rule
+	for _, name := range categoryNames {
+		rules = append(rules, categories[name]...)
+	}
+	return rules
+}
+
+// Categories are the rules of the package, by category.
+var Categories = NewCategories()
+
+var Rules = categoryRules(Categories)
 
 type wrappedRule struct {
 	tflint.Rule
@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/config"
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.RuleSet = new(RuleSet)
+
+// RuleSet is the avm ruleset. It extends the BuiltinRuleSet with the
+// plugin configuration declared in the `plugin "avm"` block.
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+	config       *config.Config
+	globalConfig *tflint.Config
+	customRules  []tflint.Rule // The rules registered for the custom interfaces.
+	categories   map[string][]tflint.Rule
+	// interfacesDir is the directory the relative `interface_files` patterns were resolved against,
+	// empty until NewRunner provides the original working directory.
+	interfacesDir string
+	// configuredRules are the rules enabled by the configuration, before the rules
+	// that do not apply to the module type are left out by NewRunner.
+	configuredRules []tflint.Rule
+}
+
+// NewRuleSet returns the avm ruleset with new instances of all rules registered.
+func NewRuleSet(version string) *RuleSet {
+	categories := NewCategories()
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    "avm",
+			Version: version,
+			Rules:   categoryRules(categories),
+		},
+		config:     &config.Config{},
+		categories: categories,
+	}
+}
+
+// ConfigSchema returns the schema of the `plugin "avm"` block.
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(r.config)
+}
+
+// ApplyGlobalConfig records the global configuration, so that the enabled rules can be
+// worked out again once the custom interface rules are registered by ApplyConfig.
+func (r *RuleSet) ApplyGlobalConfig(c *tflint.Config) error {
+	r.globalConfig = c
+	if err := r.BuiltinRuleSet.ApplyGlobalConfig(c); err != nil {
+		return err
+	}
+	r.configuredRules = r.EnabledRules
+	return nil
+}
+
+// ApplyConfig decodes the plugin configuration, registers a rule per custom interface,
+// pushes the configuration into the rules that accept it, and removes the rules of disabled categories.
+// It is called after ApplyGlobalConfig, so EnabledRules is already populated.
+// The custom interfaces of relative `interface_files` patterns are registered by NewRunner.
+func (r *RuleSet) ApplyConfig(body *hclext.BodyContent) error {
+	r.config = &config.Config{}
+	if diags := hclext.DecodeBody(body, nil, r.config); diags.HasErrors() {
+		return diags
+	}
+	if err := r.config.Validate(); err != nil {
+		return err
+	}
+	return r.configure("")
+}
+
+// configure registers the custom interfaces, with the relative `interface_files` patterns resolved
+// against wd or left out if wd is empty, and applies the plugin configuration to the rules.
+func (r *RuleSet) configure(wd string) error {
+	customRules, err := r.registerCustomInterfaces(wd)
+	if err != nil {
+		return err
+	}
+
+	var disabled []tflint.Rule
+	categories := r.categories
+	if categories == nil {
+		categories = Categories
+	}
+	for _, c := range r.config.Categories {
+		rules, ok := categories[c.Name]
+		if !ok {
+			return fmt.Errorf("unknown rule category %q", c.Name)
+		}
+		if !c.Enabled {
+			disabled = append(disabled, rules...)
+			// The custom interface rules belong to the interfaces category.
+			if c.Name == "interfaces" {
+				disabled = append(disabled, customRules...)
+			}
+		}
+	}
+
+	for _, rule := range r.Rules {
+		c, ok := rule.(config.Configurable)
+		if !ok {
+			continue
+		}
+		if err := c.ApplyConfig(r.config); err != nil {
+			return fmt.Errorf("applying config to rule %s: %w", rule.Name(), err)
+		}
+	}
+
+	r.EnabledRules = slices.DeleteFunc(r.EnabledRules, func(rule tflint.Rule) bool {
+		return slices.Contains(disabled, rule)
+	})
+	r.configuredRules = r.EnabledRules
+	return nil
+}
+
+// NewRunner registers the custom interfaces of relative `interface_files` patterns, resolved against
+// the directory TFLint was run from, which is only known once there is a runner.
+// It then works out the type of the module being checked, see resolveModuleType,
+// and leaves out the enabled rules that do not apply to it. The rules are run once it returns.
+func (r *RuleSet) NewRunner(runner tflint.Runner) (tflint.Runner, error) {
+	if slices.ContainsFunc(r.config.InterfaceFiles, isRelative) {
+		wd, err := runner.GetOriginalwd()
+		if err != nil {
+			return nil, err
+		}
+		if wd != r.interfacesDir {
+			if err := r.configure(wd); err != nil {
+				return nil, err
+			}
+		}
+	}
+	moduleType, err := resolveModuleType(runner, r.config.ModuleType)
+	if err != nil {
+		return nil, err
+	}
+	r.EnabledRules = slices.DeleteFunc(slices.Clone(r.configuredRules), func(rule tflint.Rule) bool {
+		return !ruleAppliesTo(rule, moduleType)
+	})
+	return r.BuiltinRuleSet.NewRunner(runner)
+}
+
+// registerCustomInterfaces adds a rule per interface of the `interface_files` of the plugin configuration
+// to the rules of the ruleset, and checks that the `interface` blocks refer to known interfaces.
+// The relative patterns are resolved against wd, they are left out if wd is empty.
+func (r *RuleSet) registerCustomInterfaces(wd string) ([]tflint.Rule, error) {
+	patterns := r.config.InterfaceFiles
+	if wd == "" {
+		patterns = slices.DeleteFunc(slices.Clone(patterns), isRelative)
+	}
+	pending := len(patterns) < len(r.config.InterfaceFiles)
+	custom, err := interfaces.LoadCustomInterfaces(wd, patterns)
+	if err != nil {
+		return nil, fmt.Errorf("loading custom interfaces: %w", err)
+	}
+	r.interfacesDir = wd
+	for _, ic := range r.config.Interfaces {
+		isCustom := slices.ContainsFunc(custom, func(i interfaces.AvmInterface) bool { return i.RuleName == ic.Name })
+		// The interface may be declared by a file that is not loaded yet.
+		if !isCustom && !pending && !interfaces.IsKnownInterface(ic.Name) {
+			return nil, fmt.Errorf("unknown interface %q", ic.Name)
+		}
+	}
+
+	// Drop the rules registered by a previous call, the configuration may have changed.
+	builtin := slices.DeleteFunc(slices.Clone(r.Rules), func(rule tflint.Rule) bool {
+		return slices.Contains(r.customRules, rule)
+	})
+	registered := len(r.customRules) > 0
+	r.customRules = make([]tflint.Rule, 0, len(custom))
+	for _, i := range custom {
+		if slices.ContainsFunc(builtin, func(rule tflint.Rule) bool { return rule.Name() == i.RuleName }) {
+			return nil, fmt.Errorf("custom interface %s: a rule with the same name already exists", i.RuleName)
+		}
+		r.customRules = append(r.customRules, interfaces.NewVarCheckRuleFromAvmInterface(i))
+	}
+	r.Rules = slices.Concat(builtin, r.customRules)
+	if (!registered && len(r.customRules) == 0) || r.globalConfig == nil {
+		return r.customRules, nil
+	}
+	// Work out the enabled rules again, now that the custom rules are registered.
+	return r.customRules, r.BuiltinRuleSet.ApplyGlobalConfig(r.globalConfig)
+}
+
+func isRelative(pattern string) bool {
+	return !filepath.IsAbs(pattern)
+}
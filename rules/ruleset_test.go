@@ -0,0 +1,372 @@
+package rules_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func applyPluginConfig(t *testing.T, rs *rules.RuleSet, src string) error {
+	file, diags := hclsyntax.ParseConfig([]byte(src), ".tflint.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	content, diags := hclext.Content(file.Body, rs.ConfigSchema())
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.NoError(t, rs.ApplyGlobalConfig(&tflint.Config{}))
+	return rs.ApplyConfig(content)
+}
+
+func enabledRuleNames(rs *rules.RuleSet) []string {
+	var names []string
+	for _, r := range rs.EnabledRules {
+		names = append(names, r.Name())
+	}
+	return names
+}
+
+func TestRuleSetApplyConfig(t *testing.T) {
+	cases := []struct {
+		desc      string
+		config    string
+		expectErr string
+		enabled   []string
+		disabled  []string
+	}{
+		{
+			desc:     "empty config enables default rules",
+			config:   ``,
+			enabled:  []string{"required_module_source_tffr1", "required_output_rmfr7", "provider_azurerm_version_constraint"},
+			disabled: []string{"tfnfr26"},
+		},
+		{
+			desc: "disabled category removes its rules",
+			config: `category "outputs" {
+  enabled = false
+}`,
+			enabled:  []string{"required_module_source_tffr1"},
+			disabled: []string{"required_output_rmfr7"},
+		},
+		{
+			desc: "unknown category is an error",
+			config: `category "foo" {
+  enabled = false
+}`,
+			expectErr: `unknown rule category "foo"`,
+		},
+		{
+			desc:      "invalid module type is an error",
+			config:    `module_type = "foo"`,
+			expectErr: `invalid module_type "foo"`,
+		},
+		{
+			desc:      "invalid spec version is an error",
+			config:    `spec_version = "2024-5-1"`,
+			expectErr: `invalid spec_version "2024-5-1", must be a date in the YYYY-MM-DD format`,
+		},
+		{
+			desc:    "spec version date",
+			config:  `spec_version = "2024-05-01"`,
+			enabled: []string{"role_assignments"},
+		},
+		{
+			desc: "invalid provider version is an error",
+			config: `provider "azapi" {
+  version = "not-a-version"
+}`,
+			expectErr: "invalid version for provider azapi",
+		},
+		{
+			desc:      "invalid allowed module source is an error",
+			config:    `allowed_module_sources = ["contoso/[a-"]`,
+			expectErr: `invalid allowed module source "contoso/[a-"`,
+		},
+		{
+			desc: "unknown interface is an error",
+			config: `interface "foo" {
+  required = false
+}`,
+			expectErr: `unknown interface "foo"`,
+		},
+		{
+			desc: "unknown interface revision is an error",
+			config: `interface "role_assignments" {
+  revision = "v9"
+}`,
+			expectErr: `unknown revision "v9" for interface role_assignments`,
+		},
+		{
+			desc: "interface revision selects the revision",
+			config: `interface "private_endpoints" {
+  revision = "v1"
+}`,
+			enabled: []string{"private_endpoints"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rs := rules.NewRuleSet("test")
+			err := applyPluginConfig(t, rs, tc.config)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			names := enabledRuleNames(rs)
+			for _, n := range tc.enabled {
+				assert.Contains(t, names, n)
+			}
+			for _, n := range tc.disabled {
+				assert.NotContains(t, names, n)
+			}
+		})
+	}
+}
+
+func TestRuleSetsDoNotShareRules(t *testing.T) {
+	configured := rules.NewRuleSet("test")
+	require.NoError(t, applyPluginConfig(t, configured, `provider "azurerm" {
+  version = "3.999.0"
+}`))
+
+	for _, rule := range rules.NewRuleSet("test").Rules {
+		if r, ok := rule.(*rules.ProviderVersionRule); ok && r.ProviderName == "azurerm" {
+			assert.Equal(t, "4.999.0", r.Version)
+			return
+		}
+	}
+	t.Fatal("provider_azurerm_version_constraint rule not found")
+}
+
+func TestProviderVersionRuleApplyConfig(t *testing.T) {
+	rule := rules.NewProviderVersionRule("azurerm", "hashicorp/azurerm", "4.999.0", "~> 4.0", false)
+	rs := &rules.RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Rules: []tflint.Rule{rule},
+		},
+	}
+	err := applyPluginConfig(t, rs, `provider "azurerm" {
+  version                = "3.999.0"
+  recommended_constraint = "~> 3.0"
+  must_exist             = true
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, "3.999.0", rule.Version)
+	assert.Equal(t, "~> 3.0", rule.RecommendedConstraint)
+	assert.True(t, rule.MustExist)
+}
+
+func TestRuleSetCustomInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "timeouts.hcl"), []byte(`
+interface "timeouts" {
+  link     = "https://example.com/interfaces/timeouts"
+  severity = "warning"
+  revision = "v1"
+
+  type = object({
+    create = optional(string, "30m")
+    delete = optional(string, "30m")
+  })
+  default  = {}
+  nullable = false
+}
+
+interface "network_rules" {
+  revision = "v1"
+
+  type = object({
+    default_action = optional(string, "Deny")
+    lock           = optional(interface.lock, null)
+  })
+  default  = null
+}
+`), 0o600))
+	pattern := filepath.Join(dir, "*.hcl")
+
+	t.Run("registers a rule per custom interface", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		require.NoError(t, applyPluginConfig(t, rs, fmt.Sprintf(`interface_files = [%q]
+interface "timeouts" {
+  revision = "v1"
+}`, pattern)))
+		assert.Contains(t, enabledRuleNames(rs), "network_rules")
+		assert.Contains(t, enabledRuleNames(rs), "timeouts")
+		assert.Contains(t, rs.RuleNames(), "timeouts")
+
+		var rule tflint.Rule
+		for _, r := range rs.EnabledRules {
+			if r.Name() == "timeouts" {
+				rule = r
+			}
+		}
+		assert.Equal(t, tflint.WARNING, rule.Severity())
+		runner := helper.TestRunner(t, map[string]string{"variables.tf": `
+variable "timeouts" {
+  type = object({
+    create = optional(string, "30m")
+  })
+  default  = {}
+  nullable = false
+}`})
+		require.NoError(t, rule.Check(runner))
+		require.Len(t, runner.Issues, 1)
+		assert.Contains(t, runner.Issues[0].Message, "variable type does not comply with the interface specification")
+	})
+
+	t.Run("applying the config again drops the previous custom rules", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		require.NoError(t, applyPluginConfig(t, rs, fmt.Sprintf(`interface_files = [%q]`, pattern)))
+		require.NoError(t, applyPluginConfig(t, rs, ``))
+		assert.NotContains(t, rs.RuleNames(), "timeouts")
+		assert.NotContains(t, enabledRuleNames(rs), "timeouts")
+	})
+
+	t.Run("disabled interfaces category disables the custom rules", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		require.NoError(t, applyPluginConfig(t, rs, fmt.Sprintf(`interface_files = [%q]
+category "interfaces" {
+  enabled = false
+}`, pattern)))
+		assert.NotContains(t, enabledRuleNames(rs), "timeouts")
+	})
+
+	t.Run("missing files are an error", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		err := applyPluginConfig(t, rs, fmt.Sprintf(`interface_files = [%q]`, filepath.Join(dir, "*.json")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no interface file matches")
+	})
+
+	t.Run("redefining a built-in interface is an error", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "tags.hcl")
+		require.NoError(t, os.WriteFile(file, []byte(`
+interface "my_tags" {
+  variable = "tags"
+  revision = "v1"
+  type     = map(any)
+}`), 0o600))
+		rs := rules.NewRuleSet("test")
+		err := applyPluginConfig(t, rs, fmt.Sprintf(`interface_files = [%q]`, file))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the tags variable is already checked by another interface")
+	})
+
+	t.Run("relative patterns are resolved against the original working directory", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		require.NoError(t, applyPluginConfig(t, rs, `interface_files = ["*.hcl"]
+interface "timeouts" {
+  revision = "v1"
+}`))
+		assert.NotContains(t, enabledRuleNames(rs), "timeouts")
+
+		// The plugin runs in the package directory, which has no interface files.
+		runner := &originalwdRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": ``}), wd: dir}
+		_, err := rs.NewRunner(runner)
+		require.NoError(t, err)
+		assert.Contains(t, enabledRuleNames(rs), "timeouts")
+		assert.Contains(t, enabledRuleNames(rs), "network_rules")
+	})
+
+	t.Run("unknown interface with relative patterns is an error once they are loaded", func(t *testing.T) {
+		rs := rules.NewRuleSet("test")
+		require.NoError(t, applyPluginConfig(t, rs, `interface_files = ["*.hcl"]
+interface "foo" {
+  required = false
+}`))
+		runner := &originalwdRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": ``}), wd: dir}
+		_, err := rs.NewRunner(runner)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown interface "foo"`)
+	})
+}
+
+// originalwdRunner is a runner for a TFLint run started in another directory than the working directory of the plugin.
+type originalwdRunner struct {
+	*helper.Runner
+	wd string
+}
+
+func (r *originalwdRunner) GetOriginalwd() (string, error) {
+	return r.wd, nil
+}
+
+func TestRuleSetModuleTypes(t *testing.T) {
+	cases := []struct {
+		desc     string
+		config   string
+		files    map[string]string
+		enabled  []string
+		disabled []string
+	}{
+		{
+			desc:    "unknown module type runs all rules",
+			files:   map[string]string{"main.tf": ``},
+			enabled: []string{"required_module_source_tffr1", "required_output_rmfr7", "required_interfaces", "lock_usage"},
+		},
+		{
+			desc:     "configured pattern module",
+			config:   `module_type = "pattern"`,
+			files:    map[string]string{"main.tf": ``},
+			enabled:  []string{"provider_azurerm_version_constraint", "lock", "required_module_source_tffr1", "telemetry_usage"},
+			disabled: []string{"required_output_rmfr7", "required_interfaces", "lock_usage", "role_assignments_usage", "private_endpoints_usage"},
+		},
+		{
+			desc:     "utility module inferred from the directory",
+			files:    map[string]string{"terraform-azurerm-avm-utl-regions/main.tf": ``},
+			enabled:  []string{"required_module_source_tffr1"},
+			disabled: []string{"required_output_rmfr7", "required_interfaces"},
+		},
+		{
+			desc: "directory of the first file in name order",
+			files: map[string]string{
+				"terraform-azurerm-avm-ptn-aks/main.tf":   ``,
+				"terraform-azurerm-avm-res-vault/main.tf": ``,
+			},
+			enabled:  []string{"required_module_source_tffr1"},
+			disabled: []string{"required_output_rmfr7", "required_interfaces"},
+		},
+		{
+			desc:    "configured type wins over the directory",
+			config:  `module_type = "resource"`,
+			files:   map[string]string{"terraform-azurerm-avm-ptn-aks/main.tf": ``},
+			enabled: []string{"required_module_source_tffr1", "required_output_rmfr7", "required_interfaces"},
+		},
+		{
+			desc: "pattern module inferred from the telemetry source",
+			files: map[string]string{"main.telemetry.tf": `resource "modtm_telemetry" "telemetry" {
+  tags = {
+    module_source = "registry.terraform.io/Azure/avm-ptn-aks-production/azurerm"
+  }
+}`},
+			enabled:  []string{"required_module_source_tffr1"},
+			disabled: []string{"required_output_rmfr7"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rs := rules.NewRuleSet("test")
+			require.NoError(t, applyPluginConfig(t, rs, tc.config))
+			_, err := rs.NewRunner(helper.TestRunner(t, tc.files))
+			require.NoError(t, err)
+			names := enabledRuleNames(rs)
+			for _, n := range tc.enabled {
+				assert.Contains(t, names, n)
+			}
+			for _, n := range tc.disabled {
+				assert.NotContains(t, names, n)
+			}
+		})
+	}
+}